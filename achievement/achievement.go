@@ -0,0 +1,180 @@
+package achievement
+
+import (
+	"time"
+
+	"github.com/litencatt/pkr/entity"
+)
+
+// EventType is a kind of game event achievements are evaluated against.
+type EventType string
+
+const (
+	HandPlayed   EventType = "hand_played"
+	RoundCleared EventType = "round_cleared"
+	RunEnded     EventType = "run_ended"
+)
+
+// Event is something that happened in a run.
+type Event struct {
+	Type EventType
+	// Hand is the played hand of a HandPlayed event.
+	Hand entity.PokerHandStats
+	// HandsUsed and DiscardsUsed are counted in the cleared round of a
+	// RoundCleared event.
+	HandsUsed    int
+	DiscardsUsed int
+	// Ante is the ante the event happened in.
+	Ante int
+	// Won and CardsDiscarded describe the run of a RunEnded event.
+	Won            bool
+	CardsDiscarded int
+}
+
+// Achievement is earned once Goal events have matched.
+type Achievement struct {
+	ID          string
+	Name        string
+	Description string
+	Goal        int
+	match       func(Event) bool
+}
+
+// All returns every achievement.
+func All() []Achievement {
+	return []Achievement{
+		{
+			ID: "first_blind", Name: "Beginner", Description: "Clear your first blind", Goal: 1,
+			match: func(e Event) bool { return e.Type == RoundCleared },
+		},
+		{
+			ID: "one_shot", Name: "One Shot", Description: "Clear a blind using only one hand", Goal: 1,
+			match: func(e Event) bool { return e.Type == RoundCleared && e.HandsUsed == 1 },
+		},
+		{
+			ID: "royal_flush", Name: "Royalty", Description: "Play a Royal Flush", Goal: 1,
+			match: func(e Event) bool { return e.Type == HandPlayed && e.Hand.HandType == entity.RoyalFlush },
+		},
+		{
+			ID: "flushes", Name: "Flush Fan", Description: "Play 50 Flushes", Goal: 50,
+			match: func(e Event) bool { return e.Type == HandPlayed && e.Hand.HandType == entity.Flush },
+		},
+		{
+			ID: "hands", Name: "Card Shark", Description: "Play 500 hands", Goal: 500,
+			match: func(e Event) bool { return e.Type == HandPlayed },
+		},
+		{
+			ID: "ante_3", Name: "Getting Warm", Description: "Reach ante 3", Goal: 1,
+			match: func(e Event) bool { return e.Ante >= 3 },
+		},
+		{
+			ID: "ante_5", Name: "High Stakes", Description: "Reach ante 5", Goal: 1,
+			match: func(e Event) bool { return e.Ante >= 5 },
+		},
+		{
+			ID: "ante_8", Name: "High Roller", Description: "Reach ante 8", Goal: 1,
+			match: func(e Event) bool { return e.Ante >= 8 },
+		},
+		{
+			ID: "no_discards", Name: "Purist", Description: "Win a run without discarding", Goal: 1,
+			match: func(e Event) bool { return e.Type == RunEnded && e.Won && e.CardsDiscarded == 0 },
+		},
+	}
+}
+
+// Find returns the achievement with the given ID.
+func Find(id string) (Achievement, bool) {
+	for _, a := range All() {
+		if a.ID == id {
+			return a, true
+		}
+	}
+	return Achievement{}, false
+}
+
+// Unlock is content that is locked until an achievement is earned.
+type Unlock struct {
+	// Kind is the kind of content, such as "deck", "stake" or "joker".
+	Kind        string
+	Name        string
+	Achievement string
+}
+
+// Unlocks returns all locked content.
+func Unlocks() []Unlock {
+	return []Unlock{
+		{Kind: "deck", Name: "Red", Achievement: "first_blind"},
+		{Kind: "deck", Name: "Blue", Achievement: "one_shot"},
+		{Kind: "deck", Name: "Abandoned", Achievement: "ante_3"},
+		{Kind: "stake", Name: "Red", Achievement: "first_blind"},
+		{Kind: "stake", Name: "Green", Achievement: "ante_5"},
+		{Kind: "stake", Name: "Gold", Achievement: "ante_8"},
+	}
+}
+
+// Progress is the achievement progress stored in the player profile.
+type Progress struct {
+	Counts   map[string]int       `json:"counts"`
+	Unlocked map[string]time.Time `json:"unlocked"`
+}
+
+// NewProgress returns progress with nothing earned.
+func NewProgress() Progress {
+	return Progress{
+		Counts:   make(map[string]int),
+		Unlocked: make(map[string]time.Time),
+	}
+}
+
+// Apply counts the event and returns the achievements it earned.
+func (p *Progress) Apply(e Event, now time.Time) []Achievement {
+	if p.Counts == nil {
+		p.Counts = make(map[string]int)
+	}
+	if p.Unlocked == nil {
+		p.Unlocked = make(map[string]time.Time)
+	}
+
+	var earned []Achievement
+	for _, a := range All() {
+		if p.IsEarned(a.ID) || !a.match(e) {
+			continue
+		}
+		p.Counts[a.ID]++
+		if p.Counts[a.ID] >= a.Goal {
+			p.Unlocked[a.ID] = now
+			earned = append(earned, a)
+		}
+	}
+	return earned
+}
+
+// IsEarned reports whether the achievement has been earned.
+func (p Progress) IsEarned(id string) bool {
+	_, ok := p.Unlocked[id]
+	return ok
+}
+
+// Locked returns the achievement required for the content, if the content
+// is still locked.
+func (p Progress) Locked(kind, name string) (Achievement, bool) {
+	for _, u := range Unlocks() {
+		if u.Kind != kind || u.Name != name {
+			continue
+		}
+		if a, locked := p.Requires(u.Achievement); locked {
+			return a, true
+		}
+	}
+	return Achievement{}, false
+}
+
+// Requires returns the achievement with the given ID if it is not earned
+// yet, for content that names the achievement unlocking it. An empty ID
+// requires nothing.
+func (p Progress) Requires(id string) (Achievement, bool) {
+	if id == "" || p.IsEarned(id) {
+		return Achievement{}, false
+	}
+	return Find(id)
+}
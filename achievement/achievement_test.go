@@ -0,0 +1,91 @@
+package achievement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/litencatt/pkr/entity"
+)
+
+func TestApply(t *testing.T) {
+	p := NewProgress()
+	now := time.Now()
+
+	earned := p.Apply(Event{Type: RoundCleared, HandsUsed: 1, Ante: 1}, now)
+	if len(earned) != 2 || earned[0].ID != "first_blind" || earned[1].ID != "one_shot" {
+		t.Errorf("Apply() earned %v, want first_blind and one_shot", earned)
+	}
+
+	// Earned achievements are not earned again
+	if earned := p.Apply(Event{Type: RoundCleared, HandsUsed: 1, Ante: 1}, now); len(earned) != 0 {
+		t.Errorf("Apply() earned %v again", earned)
+	}
+
+	for i := 0; i < 49; i++ {
+		if earned := p.Apply(Event{Type: HandPlayed, Hand: entity.PokerHandStats{HandType: entity.Flush}, Ante: 1}, now); len(earned) != 0 {
+			t.Fatalf("Apply() earned %v after %d Flushes", earned, i+1)
+		}
+	}
+	earned = p.Apply(Event{Type: HandPlayed, Hand: entity.PokerHandStats{HandType: entity.Flush}, Ante: 1}, now)
+	if len(earned) != 1 || earned[0].ID != "flushes" {
+		t.Errorf("Apply() earned %v, want flushes", earned)
+	}
+	if p.Counts["hands"] != 50 {
+		t.Errorf("Counts[hands] = %d, want 50", p.Counts["hands"])
+	}
+
+	earned = p.Apply(Event{Type: RunEnded, Won: true, CardsDiscarded: 0, Ante: 9}, now)
+	var ids []string
+	for _, a := range earned {
+		ids = append(ids, a.ID)
+	}
+	want := []string{"ante_3", "ante_5", "ante_8", "no_discards"}
+	if len(ids) != len(want) {
+		t.Fatalf("Apply() earned %v, want %v", ids, want)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Errorf("Apply() earned %v, want %v", ids, want)
+		}
+	}
+}
+
+func TestLocked(t *testing.T) {
+	p := NewProgress()
+
+	a, locked := p.Locked("deck", "Blue")
+	if !locked || a.ID != "one_shot" {
+		t.Errorf("Locked(deck, Blue) = %v, %v, want one_shot, true", a.ID, locked)
+	}
+	if _, locked := p.Locked("deck", "Standard"); locked {
+		t.Error("The Standard deck should never be locked")
+	}
+
+	p.Apply(Event{Type: RoundCleared, HandsUsed: 1, Ante: 1}, time.Now())
+	if _, locked := p.Locked("deck", "Blue"); locked {
+		t.Error("The Blue deck should be unlocked by one_shot")
+	}
+}
+
+func TestRequires(t *testing.T) {
+	p := NewProgress()
+	if a, locked := p.Requires("ante_3"); !locked || a.ID != "ante_3" {
+		t.Errorf("Requires(ante_3) = %v, %v, want ante_3, true", a.ID, locked)
+	}
+	if _, locked := p.Requires(""); locked {
+		t.Error("Requires() without an achievement should not lock")
+	}
+
+	p.Apply(Event{Type: RoundCleared, HandsUsed: 2, Ante: 3}, time.Now())
+	if _, locked := p.Requires("ante_3"); locked {
+		t.Error("ante_3 should be earned")
+	}
+}
+
+func TestUnlocksRequireAchievements(t *testing.T) {
+	for _, u := range Unlocks() {
+		if _, ok := Find(u.Achievement); !ok {
+			t.Errorf("%s %s requires an unknown achievement %q", u.Kind, u.Name, u.Achievement)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package advisor
+
+import (
+	"math/rand"
+
+	"github.com/litencatt/pkr/bot"
+	"github.com/litencatt/pkr/entity"
+)
+
+// DefaultSamples is the number of sampled decks used to estimate the chance
+// of clearing the round.
+const DefaultSamples = 500
+
+// Advice is the suggested play for the current hand.
+type Advice struct {
+	// Play is the indexes of the highest scoring hand cards.
+	Play      []int
+	PlayStats entity.PokerHandStats
+	// Discard is the indexes of the hand cards worth discarding.
+	Discard []int
+	// ClearChance is the estimated chance of reaching ScoreAtLeast with the
+	// remaining hands when playing greedily.
+	ClearChance float64
+}
+
+// Advise returns advice for the observed state. deck is the undrawn cards,
+// which are shuffled samples times to estimate the clear chance.
+func Advise(obs bot.Observation, deck []entity.Trump, samples int, rnd *rand.Rand) Advice {
+	play, stats := bot.BestPlay(obs.HandCards, obs.ScoreHand, obs.MaxSelectCards)
+
+	advice := Advice{
+		Play:      play,
+		PlayStats: stats,
+		Discard:   discardCandidates(obs.HandCards, play, obs.MaxSelectCards),
+	}
+
+	if samples > 0 {
+		clears := 0
+		for i := 0; i < samples; i++ {
+			sampled := append([]entity.Trump(nil), deck...)
+			rnd.Shuffle(len(sampled), func(i, j int) {
+				sampled[i], sampled[j] = sampled[j], sampled[i]
+			})
+			if simulateRound(obs, sampled) {
+				clears++
+			}
+		}
+		advice.ClearChance = float64(clears) / float64(samples)
+	}
+
+	return advice
+}
+
+// discardCandidates returns the lowest hand cards that are not part of the
+// best play.
+func discardCandidates(hand []entity.Trump, play []int, maxCards int) []int {
+	var rest []entity.Trump
+	var restIndexes []int
+	for i, card := range hand {
+		if !containsIndex(play, i) {
+			rest = append(rest, card)
+			restIndexes = append(restIndexes, i)
+		}
+	}
+
+	var discard []int
+	for _, i := range bot.LowestCards(rest, maxCards) {
+		discard = append(discard, restIndexes[i])
+	}
+	return discard
+}
+
+// simulateRound plays the rest of the round with the greedy agent against
+// the given deck order and reports whether it was cleared.
+func simulateRound(obs bot.Observation, deck []entity.Trump) bool {
+	agent := bot.NewGreedyAgent()
+	hand := append([]entity.Trump(nil), obs.HandCards...)
+
+	for obs.Hands > 0 {
+		obs.HandCards = hand
+		obs.DeckRemaining = len(deck)
+		action, err := agent.Act(obs)
+		if err != nil || len(action.Cards) == 0 {
+			return false
+		}
+
+		var selected, remain []entity.Trump
+		for i, card := range hand {
+			if containsIndex(action.Cards, i) {
+				selected = append(selected, card)
+			} else {
+				remain = append(remain, card)
+			}
+		}
+
+		if action.Type == bot.ActionDiscard {
+			obs.Discards--
+		} else {
+			obs.Hands--
+			obs.TotalScore += obs.ScoreHand(selected).Score
+			if obs.TotalScore >= obs.ScoreAtLeast {
+				return true
+			}
+		}
+
+		draw := len(selected)
+		if draw > len(deck) {
+			draw = len(deck)
+		}
+		hand = append(remain, deck[:draw]...)
+		deck = deck[draw:]
+	}
+
+	return obs.TotalScore >= obs.ScoreAtLeast
+}
+
+func containsIndex(indexes []int, i int) bool {
+	for _, index := range indexes {
+		if index == i {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package advisor
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/litencatt/pkr/bot"
+	"github.com/litencatt/pkr/entity"
+)
+
+func newObservation(scoreAtLeast int) bot.Observation {
+	return bot.Observation{
+		HandCards: []entity.Trump{
+			{Suit: entity.Clubs, Rank: entity.Two},
+			{Suit: entity.Diamonds, Rank: entity.Three},
+			{Suit: entity.Hearts, Rank: entity.Seven},
+			{Suit: entity.Spades, Rank: entity.Nine},
+			{Suit: entity.Hearts, Rank: entity.Jack},
+			{Suit: entity.Clubs, Rank: entity.Queen},
+			{Suit: entity.Diamonds, Rank: entity.King},
+			{Suit: entity.Hearts, Rank: entity.King},
+		},
+		Hands:          4,
+		Discards:       3,
+		ScoreAtLeast:   scoreAtLeast,
+		MaxSelectCards: entity.DefaultMaxSelectCards,
+		PokerHands:     entity.NewPokerHands(),
+	}
+}
+
+func remainingDeck(hand []entity.Trump) []entity.Trump {
+	var deck []entity.Trump
+	for _, card := range entity.NewDeck() {
+		if !entity.Contains(hand, card) {
+			deck = append(deck, card)
+		}
+	}
+	return deck
+}
+
+func TestAdvise(t *testing.T) {
+	obs := newObservation(300)
+	advice := Advise(obs, remainingDeck(obs.HandCards), 100, rand.New(rand.NewSource(1)))
+
+	if advice.PlayStats.HandType != entity.OnePair {
+		t.Errorf("Advise() PlayStats.HandType = %s, want %s", advice.PlayStats.HandType, entity.OnePair)
+	}
+
+	for _, i := range advice.Discard {
+		for _, j := range advice.Play {
+			if i == j {
+				t.Errorf("Advise() suggests discarding played card %d", i)
+			}
+		}
+	}
+
+	if advice.ClearChance <= 0 || advice.ClearChance > 1 {
+		t.Errorf("Advise() ClearChance = %f, want in (0, 1]", advice.ClearChance)
+	}
+}
+
+func TestAdviseClearChance(t *testing.T) {
+	easy := newObservation(1)
+	advice := Advise(easy, remainingDeck(easy.HandCards), 50, rand.New(rand.NewSource(1)))
+	if advice.ClearChance != 1 {
+		t.Errorf("Advise() ClearChance = %f, want 1 for an easy target", advice.ClearChance)
+	}
+
+	impossible := newObservation(1000000)
+	advice = Advise(impossible, remainingDeck(impossible.HandCards), 50, rand.New(rand.NewSource(1)))
+	if advice.ClearChance != 0 {
+		t.Errorf("Advise() ClearChance = %f, want 0 for an impossible target", advice.ClearChance)
+	}
+}
+
+func TestAdviseDeterministic(t *testing.T) {
+	obs := newObservation(600)
+	deck := remainingDeck(obs.HandCards)
+
+	a := Advise(obs, deck, 100, rand.New(rand.NewSource(5)))
+	b := Advise(obs, deck, 100, rand.New(rand.NewSource(5)))
+	if a.ClearChance != b.ClearChance {
+		t.Errorf("Advise() with the same seed gave %f and %f", a.ClearChance, b.ClearChance)
+	}
+}
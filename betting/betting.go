@@ -0,0 +1,416 @@
+// Package betting keeps the chip accounting of a poker hand: stacks, bets
+// on each street, raise rules, all-ins, and the main and side pots.
+package betting
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrIllegalAction is returned for an action that the betting rules do not
+// allow.
+var ErrIllegalAction = errors.New("illegal action")
+
+// Pot is the main pot or a side pot: an amount and the seats that can win
+// it, ordered from the left of the button.
+type Pot struct {
+	Amount   int
+	Eligible []int
+}
+
+// Hand is the betting of one hand of no-limit poker. Seats are indexes into
+// the stacks the hand was created with, and a seat without chips sits the
+// hand out. The caller deals the cards and decides whose turn it is.
+type Hand struct {
+	button   int
+	bigBlind int
+	stacks   []int
+	bets     []int
+	totals   []int
+	folded   []bool
+	out      []bool
+	acted    []bool
+	// actedAt is the bet a seat matched when it last acted. A seat may only
+	// raise again once the bet has grown by a full raise since then.
+	actedAt []int
+	current int
+	// minRaise is the smallest raise increment, which is the big blind or
+	// the largest full bet or raise on this street.
+	minRaise int
+}
+
+// NewHand starts a hand with the given stacks and button seat.
+func NewHand(stacks []int, button, bigBlind int) *Hand {
+	n := len(stacks)
+	h := &Hand{
+		button:   button,
+		bigBlind: bigBlind,
+		stacks:   append([]int(nil), stacks...),
+		bets:     make([]int, n),
+		totals:   make([]int, n),
+		folded:   make([]bool, n),
+		out:      make([]bool, n),
+		acted:    make([]bool, n),
+		actedAt:  make([]int, n),
+		minRaise: bigBlind,
+	}
+	for seat, stack := range stacks {
+		h.out[seat] = stack <= 0
+	}
+	return h
+}
+
+// Seats returns the number of seats.
+func (h *Hand) Seats() int {
+	return len(h.stacks)
+}
+
+// Stack returns the chips a seat has behind.
+func (h *Hand) Stack(seat int) int {
+	return h.stacks[seat]
+}
+
+// Bet returns what a seat has put in on the current street.
+func (h *Hand) Bet(seat int) int {
+	return h.bets[seat]
+}
+
+// Total returns what a seat has put in during the whole hand.
+func (h *Hand) Total(seat int) int {
+	return h.totals[seat]
+}
+
+// Folded reports whether a seat has folded.
+func (h *Hand) Folded(seat int) bool {
+	return h.folded[seat]
+}
+
+// InHand reports whether a seat can still win a pot.
+func (h *Hand) InHand(seat int) bool {
+	return !h.out[seat] && !h.folded[seat]
+}
+
+// AllIn reports whether a seat in the hand has no chips behind.
+func (h *Hand) AllIn(seat int) bool {
+	return h.InHand(seat) && h.stacks[seat] == 0
+}
+
+// Active returns the number of seats that can still win a pot.
+func (h *Hand) Active() int {
+	n := 0
+	for seat := range h.stacks {
+		if h.InHand(seat) {
+			n++
+		}
+	}
+	return n
+}
+
+// CurrentBet returns the bet to match on this street.
+func (h *Hand) CurrentBet() int {
+	return h.current
+}
+
+// Pot returns all chips put in during the hand.
+func (h *Hand) Pot() int {
+	pot := 0
+	for _, total := range h.totals {
+		pot += total
+	}
+	return pot
+}
+
+// ToCall returns what a seat has to add to stay in the hand, which is less
+// than the bet when the seat is short.
+func (h *Hand) ToCall(seat int) int {
+	return min(h.current-h.bets[seat], h.stacks[seat])
+}
+
+// MinRaise returns the smallest total a seat may raise to, unless it goes
+// all in for less.
+func (h *Hand) MinRaise(seat int) int {
+	return min(h.current+h.minRaise, h.MaxRaise(seat))
+}
+
+// MaxRaise returns the largest total a seat may raise to.
+func (h *Hand) MaxRaise(seat int) int {
+	return h.bets[seat] + h.stacks[seat]
+}
+
+// CanRaise reports whether a seat may bet or raise. It may not when it
+// cannot cover more than a call, when nobody else could call, or when it
+// has already acted and the bet has since grown by less than a full raise.
+func (h *Hand) CanRaise(seat int) bool {
+	if !h.canAct(seat) || h.stacks[seat] <= h.ToCall(seat) || !h.othersCanAct(seat) {
+		return false
+	}
+	return !h.acted[seat] || h.current-h.actedAt[seat] >= h.minRaise
+}
+
+// NeedsAction reports whether a seat still has to act on this street.
+func (h *Hand) NeedsAction(seat int) bool {
+	if !h.canAct(seat) || (h.acted[seat] && h.bets[seat] >= h.current) {
+		return false
+	}
+	if !h.othersCanAct(seat) {
+		// Nobody to bet against, and nothing to call that anybody could win
+		return h.ToCall(seat) > 0 && !h.covers(seat)
+	}
+	return true
+}
+
+// covers reports whether a seat has put in at least the bet of every other
+// seat in the hand.
+func (h *Hand) covers(seat int) bool {
+	for other := range h.stacks {
+		if h.InHand(other) && h.bets[other] > h.bets[seat] {
+			return false
+		}
+	}
+	return true
+}
+
+// Done reports whether the street is over.
+func (h *Hand) Done() bool {
+	if h.Active() <= 1 {
+		return true
+	}
+	for seat := range h.stacks {
+		if h.NeedsAction(seat) {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *Hand) canAct(seat int) bool {
+	return h.InHand(seat) && h.stacks[seat] > 0
+}
+
+func (h *Hand) othersCanAct(seat int) bool {
+	for other := range h.stacks {
+		if other != seat && h.canAct(other) {
+			return true
+		}
+	}
+	return false
+}
+
+// Post puts a forced bet such as a blind or an ante in front of a seat. It
+// does not count as acting, and returns the amount posted, which is less
+// when the seat is short. The others still have to match the full amount,
+// so a short big blind does not lower the bet.
+func (h *Hand) Post(seat, amount int) int {
+	if h.out[seat] {
+		return 0
+	}
+	posted := h.pay(seat, amount)
+	h.current = max(h.current, amount)
+	return posted
+}
+
+// Fold gives up the hand.
+func (h *Hand) Fold(seat int) error {
+	if err := h.checkTurn(seat); err != nil {
+		return err
+	}
+	h.folded[seat] = true
+	h.act(seat)
+	return nil
+}
+
+// Check passes when there is nothing to call.
+func (h *Hand) Check(seat int) error {
+	if err := h.checkTurn(seat); err != nil {
+		return err
+	}
+	if h.ToCall(seat) > 0 {
+		return fmt.Errorf("%w: cannot check facing a bet of %d", ErrIllegalAction, h.current)
+	}
+	h.act(seat)
+	return nil
+}
+
+// Call matches the current bet, or puts the seat all in when it is short.
+func (h *Hand) Call(seat int) error {
+	if err := h.checkTurn(seat); err != nil {
+		return err
+	}
+	if h.ToCall(seat) == 0 {
+		return fmt.Errorf("%w: nothing to call", ErrIllegalAction)
+	}
+	h.pay(seat, h.ToCall(seat))
+	h.act(seat)
+	return nil
+}
+
+// RaiseTo bets or raises so that the seat's bet on this street is amount.
+// A raise smaller than MinRaise is only allowed as an all-in, and it does
+// not reopen the betting for seats that have already acted.
+func (h *Hand) RaiseTo(seat, amount int) error {
+	if err := h.checkTurn(seat); err != nil {
+		return err
+	}
+	if !h.CanRaise(seat) {
+		return fmt.Errorf("%w: cannot raise", ErrIllegalAction)
+	}
+	if most := h.MaxRaise(seat); amount > most {
+		return fmt.Errorf("%w: raise to %d is more than the %d chips left", ErrIllegalAction, amount, most)
+	}
+	if least := h.MinRaise(seat); amount < least && amount != h.MaxRaise(seat) {
+		return fmt.Errorf("%w: raise to %d is less than the minimum of %d", ErrIllegalAction, amount, least)
+	}
+
+	if increment := amount - h.current; increment >= h.minRaise {
+		h.minRaise = increment
+	}
+	h.pay(seat, amount-h.bets[seat])
+	h.current = amount
+	h.act(seat)
+	return nil
+}
+
+func (h *Hand) checkTurn(seat int) error {
+	if seat < 0 || seat >= len(h.stacks) {
+		return fmt.Errorf("%w: no seat %d", ErrIllegalAction, seat)
+	}
+	if !h.NeedsAction(seat) {
+		return fmt.Errorf("%w: seat %d has nothing to do", ErrIllegalAction, seat)
+	}
+	return nil
+}
+
+func (h *Hand) act(seat int) {
+	h.acted[seat] = true
+	h.actedAt[seat] = h.current
+}
+
+func (h *Hand) pay(seat, amount int) int {
+	amount = min(amount, h.stacks[seat])
+	h.stacks[seat] -= amount
+	h.bets[seat] += amount
+	h.totals[seat] += amount
+	return amount
+}
+
+// EndStreet closes the betting on the current street. A bet that nobody
+// could match in full is returned to the seat that made it, and that seat
+// and the returned amount are reported.
+func (h *Hand) EndStreet() (int, int) {
+	top, second := -1, 0
+	for seat, bet := range h.bets {
+		switch {
+		case top < 0 || bet > h.bets[top]:
+			if top >= 0 {
+				second = h.bets[top]
+			}
+			top = seat
+		case bet > second:
+			second = bet
+		}
+	}
+
+	refunded := 0
+	if top >= 0 && h.bets[top] > second {
+		refunded = h.bets[top] - second
+		h.stacks[top] += refunded
+		h.totals[top] -= refunded
+	}
+
+	for seat := range h.stacks {
+		h.bets[seat] = 0
+		h.acted[seat] = false
+		h.actedAt[seat] = 0
+	}
+	h.current = 0
+	h.minRaise = h.bigBlind
+	return top, refunded
+}
+
+// Pots splits the chips into the main pot and side pots by the amounts
+// that all-in seats could match. Chips of folded seats stay in the pots
+// they were bet into.
+func (h *Hand) Pots() []Pot {
+	var levels []int
+	for seat, total := range h.totals {
+		if h.InHand(seat) {
+			levels = append(levels, total)
+		}
+	}
+	sort.Ints(levels)
+
+	var pots []Pot
+	paid := 0
+	for _, level := range levels {
+		if level == paid {
+			continue
+		}
+		pot := Pot{}
+		for _, seat := range h.fromButton() {
+			total := h.totals[seat]
+			pot.Amount += min(total, level) - min(total, paid)
+			if h.InHand(seat) && total >= level {
+				pot.Eligible = append(pot.Eligible, seat)
+			}
+		}
+		pots = append(pots, pot)
+		paid = level
+	}
+
+	// Bets of folded seats above every live bet go to the last pot
+	if len(pots) > 0 {
+		for _, total := range h.totals {
+			if total > paid {
+				pots[len(pots)-1].Amount += total - paid
+			}
+		}
+	}
+	return pots
+}
+
+// Award pays a pot to its winners and returns what each one got. A split
+// pot is shared evenly, and the odd chips go one each to the winners
+// closest to the left of the button.
+func (h *Hand) Award(pot Pot, winners []int) (map[int]int, error) {
+	if len(winners) == 0 {
+		return nil, errors.New("a pot needs at least one winner")
+	}
+	eligible := map[int]bool{}
+	for _, seat := range pot.Eligible {
+		eligible[seat] = true
+	}
+	won := map[int]bool{}
+	for _, seat := range winners {
+		if !eligible[seat] {
+			return nil, fmt.Errorf("seat %d cannot win this pot", seat)
+		}
+		won[seat] = true
+	}
+
+	payouts := map[int]int{}
+	share, odd := pot.Amount/len(won), pot.Amount%len(won)
+	for _, seat := range h.fromButton() {
+		if !won[seat] {
+			continue
+		}
+		payouts[seat] = share
+		if odd > 0 {
+			payouts[seat]++
+			odd--
+		}
+		h.stacks[seat] += payouts[seat]
+	}
+	return payouts, nil
+}
+
+// fromButton returns the seats starting left of the button and ending on
+// it.
+func (h *Hand) fromButton() []int {
+	n := len(h.stacks)
+	seats := make([]int, 0, n)
+	for i := 1; i <= n; i++ {
+		seats = append(seats, (h.button+i)%n)
+	}
+	return seats
+}
@@ -0,0 +1,382 @@
+package betting
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestBlinds(t *testing.T) {
+	h := NewHand([]int{100, 100, 100}, 0, 10)
+	if got := h.Post(1, 5); got != 5 {
+		t.Errorf("Post() = %d, want 5", got)
+	}
+	h.Post(2, 10)
+
+	if got := h.ToCall(0); got != 10 {
+		t.Errorf("ToCall() = %d, want 10", got)
+	}
+	if got := h.MinRaise(0); got != 20 {
+		t.Errorf("MinRaise() = %d, want 20", got)
+	}
+	if got := h.MaxRaise(0); got != 100 {
+		t.Errorf("MaxRaise() = %d, want 100", got)
+	}
+
+	must(t, h.Call(0))
+	must(t, h.Call(1))
+	// The big blind has the option even though nothing is owed
+	if !h.NeedsAction(2) || !h.CanRaise(2) {
+		t.Error("the big blind should get to check or raise")
+	}
+	must(t, h.Check(2))
+
+	if !h.Done() {
+		t.Error("Done() = false after everyone matched the big blind")
+	}
+	if got := h.Pot(); got != 30 {
+		t.Errorf("Pot() = %d, want 30", got)
+	}
+	if seat, refund := h.EndStreet(); refund != 0 {
+		t.Errorf("EndStreet() refunded %d to seat %d, want nothing", refund, seat)
+	}
+	if h.CurrentBet() != 0 || h.Bet(0) != 0 || h.Total(0) != 10 || h.Stack(0) != 90 {
+		t.Errorf("after the street: current %d, bet %d, total %d, stack %d", h.CurrentBet(), h.Bet(0), h.Total(0), h.Stack(0))
+	}
+	if got := h.MinRaise(1); got != 10 {
+		t.Errorf("MinRaise() on a new street = %d, want the big blind", got)
+	}
+}
+
+func TestShortBlind(t *testing.T) {
+	h := NewHand([]int{100, 3}, 0, 10)
+	h.Post(0, 5)
+	if got := h.Post(1, 10); got != 3 {
+		t.Errorf("Post() = %d, want 3", got)
+	}
+	if !h.AllIn(1) {
+		t.Error("a short big blind should be all in")
+	}
+	// The small blind already covers the short big blind
+	if h.NeedsAction(0) || !h.Done() {
+		t.Error("the button has already matched the short blind")
+	}
+}
+
+func TestShortBigBlindKeepsTheBet(t *testing.T) {
+	h := NewHand([]int{100, 100, 3}, 0, 10)
+	h.Post(1, 5)
+	h.Post(2, 10)
+
+	// The big blind is all in for 3, but the bet to match is still 10
+	if got := h.CurrentBet(); got != 10 {
+		t.Errorf("CurrentBet() = %d, want 10", got)
+	}
+	if got := h.ToCall(0); got != 10 {
+		t.Errorf("ToCall() = %d, want 10", got)
+	}
+	if got := h.MinRaise(0); got != 20 {
+		t.Errorf("MinRaise() = %d, want 20", got)
+	}
+	must(t, h.Call(0))
+	if got := h.ToCall(1); got != 5 {
+		t.Errorf("ToCall() of the small blind = %d, want 5", got)
+	}
+	must(t, h.Call(1))
+	if !h.Done() {
+		t.Error("Done() = false after the full big blind was called")
+	}
+	if got := h.Pot(); got != 23 {
+		t.Errorf("Pot() = %d, want 23", got)
+	}
+}
+
+func TestMinRaise(t *testing.T) {
+	h := NewHand([]int{1000, 1000, 1000}, 0, 10)
+	h.Post(1, 5)
+	h.Post(2, 10)
+
+	must(t, h.RaiseTo(0, 30))
+	if got := h.MinRaise(1); got != 50 {
+		t.Errorf("MinRaise() after a raise of 20 = %d, want 50", got)
+	}
+	if err := h.RaiseTo(1, 45); !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("RaiseTo(45) error = %v, want ErrIllegalAction", err)
+	}
+	must(t, h.RaiseTo(1, 80))
+	if got := h.MinRaise(2); got != 130 {
+		t.Errorf("MinRaise() after a raise of 50 = %d, want 130", got)
+	}
+	must(t, h.Call(2))
+	must(t, h.Fold(0))
+	if !h.Done() {
+		t.Error("Done() = false after the raise was called")
+	}
+	if got := h.Active(); got != 2 {
+		t.Errorf("Active() = %d, want 2", got)
+	}
+}
+
+func TestIncompleteRaise(t *testing.T) {
+	t.Run("does not reopen the betting", func(t *testing.T) {
+		h := NewHand([]int{1000, 1000, 30}, 2, 10)
+		must(t, h.RaiseTo(0, 20))
+		must(t, h.Call(1))
+		must(t, h.RaiseTo(2, 30)) // all in for 10 more
+
+		for _, seat := range []int{0, 1} {
+			if !h.NeedsAction(seat) || h.CanRaise(seat) {
+				t.Errorf("seat %d should only call or fold an incomplete raise", seat)
+			}
+			if err := h.RaiseTo(seat, 50); !errors.Is(err, ErrIllegalAction) {
+				t.Errorf("RaiseTo() error = %v, want ErrIllegalAction", err)
+			}
+		}
+		must(t, h.Call(0))
+		must(t, h.Call(1))
+		if !h.Done() {
+			t.Error("Done() = false after the all-in was called")
+		}
+	})
+
+	t.Run("a seat that has not acted may raise", func(t *testing.T) {
+		h := NewHand([]int{1000, 30, 1000}, 2, 10)
+		must(t, h.RaiseTo(0, 20))
+		must(t, h.RaiseTo(1, 30))
+		if !h.CanRaise(2) {
+			t.Error("seat 2 has not acted and should be able to raise")
+		}
+		if got := h.MinRaise(2); got != 50 {
+			t.Errorf("MinRaise() = %d, want 50", got)
+		}
+	})
+
+	t.Run("incomplete raises add up to a full raise", func(t *testing.T) {
+		h := NewHand([]int{1000, 1000, 30, 45}, 3, 10)
+		must(t, h.RaiseTo(0, 20))
+		must(t, h.Call(1))
+		must(t, h.RaiseTo(2, 30))
+		must(t, h.RaiseTo(3, 45))
+		if !h.CanRaise(0) {
+			t.Error("a bet grown by 25 since seat 0 acted should reopen the betting")
+		}
+		must(t, h.RaiseTo(0, 65))
+	})
+}
+
+func TestShortCall(t *testing.T) {
+	h := NewHand([]int{500, 60}, 0, 10)
+	must(t, h.RaiseTo(0, 100))
+	if got := h.ToCall(1); got != 60 {
+		t.Errorf("ToCall() = %d, want 60", got)
+	}
+	if h.CanRaise(1) {
+		t.Error("a seat that cannot cover the bet should not raise")
+	}
+	must(t, h.Call(1))
+	if !h.AllIn(1) || !h.Done() {
+		t.Error("calling short should put the seat all in and end the street")
+	}
+
+	seat, refund := h.EndStreet()
+	if seat != 0 || refund != 40 {
+		t.Errorf("EndStreet() = %d, %d, want the 40 uncalled chips back to seat 0", seat, refund)
+	}
+	if h.Stack(0) != 440 || h.Total(0) != 60 || h.Pot() != 120 {
+		t.Errorf("stack %d, total %d, pot %d, want 440, 60, 120", h.Stack(0), h.Total(0), h.Pot())
+	}
+	// Nobody can bet once only one seat has chips
+	if !h.Done() || h.NeedsAction(0) {
+		t.Error("the next street should need no action")
+	}
+}
+
+func TestEverybodyFolds(t *testing.T) {
+	h := NewHand([]int{100, 100, 100}, 0, 10)
+	h.Post(1, 5)
+	h.Post(2, 10)
+	must(t, h.RaiseTo(0, 30))
+	must(t, h.Fold(1))
+	must(t, h.Fold(2))
+	if !h.Done() {
+		t.Error("Done() = false with one seat left")
+	}
+
+	if seat, refund := h.EndStreet(); seat != 0 || refund != 20 {
+		t.Errorf("EndStreet() = %d, %d, want 20 back to seat 0", seat, refund)
+	}
+	pots := h.Pots()
+	if len(pots) != 1 || pots[0].Amount != 25 || !reflect.DeepEqual(pots[0].Eligible, []int{0}) {
+		t.Fatalf("Pots() = %+v, want 25 for seat 0", pots)
+	}
+	payouts, err := h.Award(pots[0], []int{0})
+	must(t, err)
+	if payouts[0] != 25 || h.Stack(0) != 115 {
+		t.Errorf("payout %d, stack %d, want 25 and 115", payouts[0], h.Stack(0))
+	}
+}
+
+func TestIllegalActions(t *testing.T) {
+	tests := []struct {
+		name string
+		act  func(h *Hand) error
+	}{
+		{"check facing a bet", func(h *Hand) error { return h.Check(0) }},
+		{"raise above the stack", func(h *Hand) error { return h.RaiseTo(0, 101) }},
+		{"raise below the minimum", func(h *Hand) error { return h.RaiseTo(0, 15) }},
+		{"no such seat", func(h *Hand) error { return h.Fold(5) }},
+		{"sitting out", func(h *Hand) error { return h.Fold(3) }},
+		{"nothing to call", func(h *Hand) error {
+			must(t, h.Call(0))
+			must(t, h.Call(1))
+			return h.Call(2)
+		}},
+		{"street is over", func(h *Hand) error {
+			must(t, h.Fold(0))
+			must(t, h.Fold(1))
+			return h.Check(2)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHand([]int{100, 100, 100, 0}, 0, 10)
+			h.Post(1, 5)
+			h.Post(2, 10)
+			if err := tt.act(h); !errors.Is(err, ErrIllegalAction) {
+				t.Errorf("error = %v, want ErrIllegalAction", err)
+			}
+		})
+	}
+}
+
+func TestPots(t *testing.T) {
+	t.Run("main pot and side pot", func(t *testing.T) {
+		h := NewHand([]int{50, 300, 300, 300}, 3, 10)
+		h.Post(0, 50)
+		h.Post(1, 200)
+		h.Post(2, 200)
+		h.Post(3, 120)
+		must(t, h.Fold(3))
+
+		want := []Pot{
+			{Amount: 200, Eligible: []int{0, 1, 2}},
+			{Amount: 370, Eligible: []int{1, 2}},
+		}
+		if got := h.Pots(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Pots() = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("several side pots", func(t *testing.T) {
+		h := NewHand([]int{30, 60, 100, 100}, 1, 10)
+		must(t, h.RaiseTo(2, 100))
+		must(t, h.Call(3))
+		must(t, h.Call(0))
+		must(t, h.Call(1))
+
+		want := []Pot{
+			{Amount: 120, Eligible: []int{2, 3, 0, 1}},
+			{Amount: 90, Eligible: []int{2, 3, 1}},
+			{Amount: 80, Eligible: []int{2, 3}},
+		}
+		if got := h.Pots(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Pots() = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("no pots before betting", func(t *testing.T) {
+		if got := NewHand([]int{100, 100}, 0, 10).Pots(); len(got) != 0 {
+			t.Errorf("Pots() = %+v, want none", got)
+		}
+	})
+}
+
+func TestAward(t *testing.T) {
+	h := NewHand([]int{100, 100, 100, 100}, 1, 10)
+	pot := Pot{Amount: 101, Eligible: []int{2, 3, 0, 1}}
+
+	// The odd chip goes to the first winner left of the button
+	payouts, err := h.Award(pot, []int{0, 3, 2})
+	must(t, err)
+	if want := map[int]int{2: 34, 3: 34, 0: 33}; !reflect.DeepEqual(payouts, want) {
+		t.Errorf("Award() = %v, want %v", payouts, want)
+	}
+	if h.Stack(2) != 134 || h.Stack(0) != 133 || h.Stack(1) != 100 {
+		t.Errorf("stacks = %d %d %d %d", h.Stack(0), h.Stack(1), h.Stack(2), h.Stack(3))
+	}
+
+	if _, err := h.Award(pot, nil); err == nil {
+		t.Error("Award() without winners should return error")
+	}
+	if _, err := h.Award(Pot{Amount: 10, Eligible: []int{2}}, []int{1}); err == nil {
+		t.Error("Award() to a seat that is not eligible should return error")
+	}
+}
+
+// TestRandomHands plays random legal actions and checks that chips are
+// never created or lost.
+func TestRandomHands(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1)) // #nosec G404 -- tests do not need secure randomness
+	for i := 0; i < 2000; i++ {
+		n := 2 + rnd.Intn(5)
+		stacks := make([]int, n)
+		chips := 0
+		for seat := range stacks {
+			stacks[seat] = rnd.Intn(300)
+			chips += stacks[seat]
+		}
+		button := rnd.Intn(n)
+		h := NewHand(stacks, button, 10)
+		h.Post((button+1)%n, 5)
+		h.Post((button+2)%n, 10)
+
+		for street := 0; street < 4 && h.Active() > 1; street++ {
+			for seat := (button + 1) % n; !h.Done(); seat = (seat + 1) % n {
+				if !h.NeedsAction(seat) {
+					continue
+				}
+				must(t, randomAction(h, seat, rnd))
+			}
+			h.EndStreet()
+		}
+
+		total := 0
+		for _, pot := range h.Pots() {
+			total += pot.Amount
+			winners := pot.Eligible[:1+rnd.Intn(len(pot.Eligible))]
+			_, err := h.Award(pot, winners)
+			must(t, err)
+		}
+		if total != h.Pot() {
+			t.Fatalf("hand %d: pots add up to %d, want %d", i, total, h.Pot())
+		}
+		for seat := range stacks {
+			chips -= h.Stack(seat)
+		}
+		if chips != 0 {
+			t.Fatalf("hand %d: %d chips went missing", i, chips)
+		}
+	}
+}
+
+func randomAction(h *Hand, seat int, rnd *rand.Rand) error {
+	switch r := rnd.Intn(10); {
+	case r < 2 && h.ToCall(seat) > 0:
+		return h.Fold(seat)
+	case r < 5 && h.CanRaise(seat):
+		low, high := h.MinRaise(seat), h.MaxRaise(seat)
+		return h.RaiseTo(seat, low+rnd.Intn(high-low+1))
+	case h.ToCall(seat) > 0:
+		return h.Call(seat)
+	default:
+		return h.Check(seat)
+	}
+}
@@ -0,0 +1,80 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/litencatt/pkr/entity"
+)
+
+type ActionType string
+
+const (
+	ActionPlay    ActionType = "play"
+	ActionDiscard ActionType = "discard"
+)
+
+// Observation is the game state an agent can see before choosing an action.
+type Observation struct {
+	HandCards     []entity.Trump `json:"hand_cards"`
+	Hands         int            `json:"hands"`
+	Discards      int            `json:"discards"`
+	TotalScore    int            `json:"total_score"`
+	ScoreAtLeast  int            `json:"score_at_least"`
+	DeckRemaining int            `json:"deck_remaining"`
+	Ante          int            `json:"ante"`
+	Rounds        int            `json:"rounds"`
+	// MaxSelectCards is the maximum number of cards in one play or discard.
+	MaxSelectCards int                `json:"max_select_cards"`
+	PokerHands     *entity.PokerHands `json:"-"`
+	// Score scores cards the way the run would, with its jokers, boss blind
+	// and challenge. Without it cards are scored by PokerHands alone.
+	Score ScoreFunc `json:"-"`
+}
+
+// ScoreFunc returns the stats of the cards if they were played.
+type ScoreFunc func(cards []entity.Trump) entity.PokerHandStats
+
+// ScoreHand returns the stats of the cards if they were played.
+func (obs Observation) ScoreHand(cards []entity.Trump) entity.PokerHandStats {
+	if obs.Score != nil {
+		return obs.Score(cards)
+	}
+	return obs.PokerHands.GetHandStats(cards)
+}
+
+// Action is a play or discard of the hand cards at the given indexes.
+type Action struct {
+	Type  ActionType `json:"action"`
+	Cards []int      `json:"cards"`
+}
+
+// Agent decides the next action from an observation.
+type Agent interface {
+	Name() string
+	Act(obs Observation) (Action, error)
+}
+
+var agentFactories = map[string]func(seed int64) Agent{
+	"random": func(seed int64) Agent { return NewRandomAgent(seed) },
+	"greedy": func(seed int64) Agent { return NewGreedyAgent() },
+}
+
+// NewAgent returns the built-in agent registered under name.
+func NewAgent(name string, seed int64) (Agent, error) {
+	factory, ok := agentFactories[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown agent %q (available: %v)", name, AgentNames())
+	}
+	return factory(seed), nil
+}
+
+// AgentNames returns the names of the built-in agents.
+func AgentNames() []string {
+	var names []string
+	for name := range agentFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
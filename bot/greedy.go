@@ -0,0 +1,81 @@
+package bot
+
+import (
+	"sort"
+
+	"github.com/litencatt/pkr/entity"
+)
+
+type greedyAgent struct{}
+
+// NewGreedyAgent returns an agent that plays the best scoring hand unless it
+// is only a High Card, in which case it discards the lowest cards.
+func NewGreedyAgent() Agent {
+	return &greedyAgent{}
+}
+
+func (a *greedyAgent) Name() string {
+	return "greedy"
+}
+
+func (a *greedyAgent) Act(obs Observation) (Action, error) {
+	cards, stats := BestPlay(obs.HandCards, obs.ScoreHand, obs.MaxSelectCards)
+
+	if stats.HandType != entity.HighCard ||
+		obs.Discards == 0 ||
+		obs.TotalScore+stats.Score >= obs.ScoreAtLeast {
+		return Action{Type: ActionPlay, Cards: cards}, nil
+	}
+
+	return Action{Type: ActionDiscard, Cards: LowestCards(obs.HandCards, obs.MaxSelectCards)}, nil
+}
+
+// BestPlay returns the indexes of up to maxCards hand cards that score the
+// most when played together, along with their hand stats.
+func BestPlay(hand []entity.Trump, score ScoreFunc, maxCards int) ([]int, entity.PokerHandStats) {
+	var best []int
+	var bestStats entity.PokerHandStats
+
+	selected := make([]entity.Trump, 0, maxCards)
+	indexes := make([]int, 0, maxCards)
+	var walk func(start int)
+	walk = func(start int) {
+		if len(indexes) > 0 {
+			stats := score(selected)
+			if best == nil || stats.Score > bestStats.Score {
+				best = append([]int(nil), indexes...)
+				bestStats = stats
+			}
+		}
+		if len(indexes) == maxCards {
+			return
+		}
+		for i := start; i < len(hand); i++ {
+			selected = append(selected, hand[i])
+			indexes = append(indexes, i)
+			walk(i + 1)
+			selected = selected[:len(selected)-1]
+			indexes = indexes[:len(indexes)-1]
+		}
+	}
+	walk(0)
+
+	return best, bestStats
+}
+
+// LowestCards returns the indexes of up to n hand cards with the lowest rank.
+func LowestCards(hand []entity.Trump, n int) []int {
+	indexes := make([]int, len(hand))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return hand[indexes[i]].GetSortOrder() < hand[indexes[j]].GetSortOrder()
+	})
+
+	if len(indexes) > n {
+		indexes = indexes[:n]
+	}
+	sort.Ints(indexes)
+	return indexes
+}
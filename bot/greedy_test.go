@@ -0,0 +1,141 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/litencatt/pkr/entity"
+)
+
+func TestBestPlay(t *testing.T) {
+	hand := []entity.Trump{
+		{Suit: entity.Clubs, Rank: entity.Two},
+		{Suit: entity.Hearts, Rank: entity.Three},
+		{Suit: entity.Hearts, Rank: entity.Seven},
+		{Suit: entity.Spades, Rank: entity.Eight},
+		{Suit: entity.Hearts, Rank: entity.Nine},
+		{Suit: entity.Hearts, Rank: entity.Jack},
+		{Suit: entity.Diamonds, Rank: entity.King},
+		{Suit: entity.Hearts, Rank: entity.King},
+	}
+
+	cards, stats := BestPlay(hand, entity.NewPokerHands().GetHandStats, entity.DefaultMaxSelectCards)
+
+	if stats.HandType != entity.Flush {
+		t.Errorf("BestPlay() HandType = %s, want %s", stats.HandType, entity.Flush)
+	}
+
+	want := []int{1, 2, 4, 5, 7}
+	if len(cards) != len(want) {
+		t.Fatalf("BestPlay() returned %d cards, want %d", len(cards), len(want))
+	}
+	for i := range want {
+		if cards[i] != want[i] {
+			t.Errorf("BestPlay() cards[%d] = %d, want %d", i, cards[i], want[i])
+		}
+	}
+}
+
+func TestBestPlayJoker(t *testing.T) {
+	hand := []entity.Trump{
+		{Suit: entity.Clubs, Rank: entity.Two},
+		{Suit: entity.Hearts, Rank: entity.Three},
+		{Suit: entity.Hearts, Rank: entity.Seven},
+		{Suit: entity.Spades, Rank: entity.Eight},
+		{Suit: entity.Hearts, Rank: entity.Nine},
+		{Suit: entity.Hearts, Rank: entity.Jack},
+		{Suit: entity.Diamonds, Rank: entity.King},
+		{Suit: entity.Hearts, Rank: entity.King},
+	}
+	// The joker makes the pair of Kings score more than the Flush
+	r := entity.NewRunInfo()
+	r.Jokers = []entity.Joker{{Name: "Pair Lover", Effects: []entity.Effect{{If: &entity.HandCondition{Contains: entity.OnePair}, Mult: 50}}}}
+	obs := Observation{HandCards: hand, PokerHands: r.PokerHands, MaxSelectCards: entity.DefaultMaxSelectCards,
+		Score: func(cards []entity.Trump) entity.PokerHandStats {
+			stats, _ := r.ScoreHand(cards)
+			return stats
+		},
+	}
+
+	cards, stats := BestPlay(hand, obs.ScoreHand, obs.MaxSelectCards)
+	if stats.HandType != entity.OnePair || !containsAll(cards, 6, 7) {
+		t.Errorf("BestPlay() = %v, %s, want the pair of Kings", cards, stats.HandType)
+	}
+	action, err := NewGreedyAgent().Act(obs)
+	if err != nil {
+		t.Fatalf("Act() returned error: %v", err)
+	}
+	if action.Type != ActionPlay || !containsAll(action.Cards, 6, 7) {
+		t.Errorf("Act() = %+v, want to play the pair of Kings", action)
+	}
+}
+
+func containsAll(indexes []int, want ...int) bool {
+	for _, w := range want {
+		found := false
+		for _, i := range indexes {
+			found = found || i == w
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func TestLowestCards(t *testing.T) {
+	hand := []entity.Trump{
+		{Suit: entity.Spades, Rank: entity.Ace},
+		{Suit: entity.Clubs, Rank: entity.Two},
+		{Suit: entity.Hearts, Rank: entity.King},
+		{Suit: entity.Diamonds, Rank: entity.Five},
+	}
+
+	cards := LowestCards(hand, 2)
+
+	want := []int{1, 3}
+	if len(cards) != len(want) {
+		t.Fatalf("LowestCards() returned %d cards, want %d", len(cards), len(want))
+	}
+	for i := range want {
+		if cards[i] != want[i] {
+			t.Errorf("LowestCards() cards[%d] = %d, want %d", i, cards[i], want[i])
+		}
+	}
+}
+
+func TestGreedyAgentDiscardsHighCard(t *testing.T) {
+	agent := NewGreedyAgent()
+	obs := Observation{
+		HandCards: []entity.Trump{
+			{Suit: entity.Clubs, Rank: entity.Two},
+			{Suit: entity.Hearts, Rank: entity.Four},
+			{Suit: entity.Spades, Rank: entity.Seven},
+			{Suit: entity.Diamonds, Rank: entity.Nine},
+			{Suit: entity.Hearts, Rank: entity.Jack},
+			{Suit: entity.Diamonds, Rank: entity.King},
+		},
+		Hands:          4,
+		Discards:       3,
+		ScoreAtLeast:   300,
+		MaxSelectCards: entity.DefaultMaxSelectCards,
+		PokerHands:     entity.NewPokerHands(),
+	}
+
+	action, err := agent.Act(obs)
+	if err != nil {
+		t.Fatalf("Act() returned error: %v", err)
+	}
+	if action.Type != ActionDiscard {
+		t.Errorf("Act() Type = %s, want %s", action.Type, ActionDiscard)
+	}
+
+	// Without discards the best hand is played
+	obs.Discards = 0
+	action, err = agent.Act(obs)
+	if err != nil {
+		t.Fatalf("Act() returned error: %v", err)
+	}
+	if action.Type != ActionPlay {
+		t.Errorf("Act() Type = %s, want %s", action.Type, ActionPlay)
+	}
+}
@@ -0,0 +1,193 @@
+package bot
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrAgentTimeout is returned when an external agent does not answer in time.
+	ErrAgentTimeout = errors.New("agent timed out")
+	// ErrAgentCrashed is returned when an external agent exits or breaks the protocol.
+	ErrAgentCrashed = errors.New("agent crashed")
+)
+
+// DefaultMoveTimeout is how long an external agent may take for one move.
+const DefaultMoveTimeout = 5 * time.Second
+
+const stderrLimit = 4096
+
+// request is a message sent to an external agent, one JSON object per line.
+type request struct {
+	Type string `json:"type"`
+	Observation
+}
+
+type processAgent struct {
+	name    string
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	lines   chan []byte
+	stderr  *tailBuffer
+	timeout time.Duration
+
+	waitOnce sync.Once
+	waitErr  error
+}
+
+// NewProcessAgent starts an external agent that speaks the bot protocol on
+// stdin and stdout. For every move the agent receives an observation line
+// like {"type":"observation","hand_cards":[...],...} and must answer with
+// an action line like {"action":"play","cards":[0,2,4]}. Stdin is closed
+// when the run is over.
+func NewProcessAgent(command []string, timeout time.Duration) (Agent, error) {
+	if len(command) == 0 {
+		return nil, errors.New("agent command is empty")
+	}
+
+	cmd := exec.Command(command[0], command[1:]...) // #nosec G204 -- running the user's agent is the point
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr := &tailBuffer{limit: stderrLimit}
+	cmd.Stderr = stderr
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("start agent: %w", err)
+	}
+
+	a := &processAgent{
+		name:    filepath.Base(command[0]),
+		cmd:     cmd,
+		stdin:   stdin,
+		lines:   make(chan []byte),
+		stderr:  stderr,
+		timeout: timeout,
+	}
+	go a.readLines(stdout)
+
+	return a, nil
+}
+
+func (a *processAgent) readLines(stdout io.Reader) {
+	defer close(a.lines)
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		line := append([]byte(nil), scanner.Bytes()...)
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+		a.lines <- line
+	}
+}
+
+func (a *processAgent) Name() string {
+	return a.name
+}
+
+func (a *processAgent) Act(obs Observation) (Action, error) {
+	msg, err := json.Marshal(request{Type: "observation", Observation: obs})
+	if err != nil {
+		return Action{}, err
+	}
+	if _, err := a.stdin.Write(append(msg, '\n')); err != nil {
+		return Action{}, a.crashed()
+	}
+
+	timer := time.NewTimer(a.timeout)
+	defer timer.Stop()
+
+	select {
+	case line, ok := <-a.lines:
+		if !ok {
+			return Action{}, a.crashed()
+		}
+		var action Action
+		if err := json.Unmarshal(line, &action); err != nil {
+			_ = a.cmd.Process.Kill()
+			return Action{}, fmt.Errorf("%w: invalid response %q: %v", ErrAgentCrashed, line, err)
+		}
+		return action, nil
+	case <-timer.C:
+		_ = a.cmd.Process.Kill()
+		return Action{}, fmt.Errorf("%w: no response within %s", ErrAgentTimeout, a.timeout)
+	}
+}
+
+// Close closes the agent's stdin and waits for it to exit, killing it if it
+// does not exit within the move timeout.
+func (a *processAgent) Close() error {
+	_ = a.stdin.Close()
+
+	done := make(chan struct{})
+	go func() {
+		a.wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(a.timeout):
+		_ = a.cmd.Process.Kill()
+		<-done
+	}
+	return nil
+}
+
+// wait drains the agent's stdout and waits for it to exit.
+func (a *processAgent) wait() error {
+	a.waitOnce.Do(func() {
+		for range a.lines {
+		}
+		a.waitErr = a.cmd.Wait()
+	})
+	return a.waitErr
+}
+
+func (a *processAgent) crashed() error {
+	_ = a.cmd.Process.Kill()
+	err := fmt.Errorf("%w: agent exited", ErrAgentCrashed)
+	if waitErr := a.wait(); waitErr != nil {
+		err = fmt.Errorf("%w: agent exited: %v", ErrAgentCrashed, waitErr)
+	}
+	if stderr := strings.TrimSpace(a.stderr.String()); stderr != "" {
+		err = fmt.Errorf("%w: %s", err, stderr)
+	}
+	return err
+}
+
+// tailBuffer keeps the last limit bytes written to it.
+type tailBuffer struct {
+	mu    sync.Mutex
+	buf   []byte
+	limit int
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf = append(b.buf, p...)
+	if len(b.buf) > b.limit {
+		b.buf = b.buf[len(b.buf)-b.limit:]
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return string(b.buf)
+}
@@ -0,0 +1,98 @@
+package bot
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/litencatt/pkr/service"
+)
+
+// TestHelperProcess is not a real test. It is run as an external agent by
+// the tests below.
+func TestHelperProcess(t *testing.T) {
+	mode := os.Getenv("PKR_BOT_HELPER")
+	if mode == "" {
+		return
+	}
+	defer os.Exit(0)
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		var obs Observation
+		if err := json.Unmarshal(scanner.Bytes(), &obs); err != nil {
+			os.Exit(2)
+		}
+
+		switch mode {
+		case "play":
+			fmt.Println(`{"action":"play","cards":[0,1,2,3,4]}`)
+		case "crash":
+			fmt.Fprintln(os.Stderr, "something went wrong")
+			os.Exit(3)
+		case "slow":
+			time.Sleep(time.Minute)
+		case "garbage":
+			fmt.Println("not json")
+		}
+	}
+}
+
+func newHelperAgent(t *testing.T, mode string) Agent {
+	t.Helper()
+	t.Setenv("PKR_BOT_HELPER", mode)
+
+	agent, err := NewProcessAgent([]string{os.Args[0], "-test.run=TestHelperProcess"}, time.Second)
+	if err != nil {
+		t.Fatalf("NewProcessAgent() returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = agent.(*processAgent).Close() })
+	return agent
+}
+
+func TestProcessAgentRun(t *testing.T) {
+	agent := newHelperAgent(t, "play")
+
+	svc := service.NewPokerService(service.NewPokerServiceConfig())
+	result, err := Run(svc, agent)
+	if err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+
+	if len(result.PlayedHands()) == 0 {
+		t.Error("Result.PlayedHands() should not be empty")
+	}
+}
+
+func TestProcessAgentErrors(t *testing.T) {
+	tests := []struct {
+		mode string
+		want error
+	}{
+		{"crash", ErrAgentCrashed},
+		{"slow", ErrAgentTimeout},
+		{"garbage", ErrAgentCrashed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			agent := newHelperAgent(t, tt.mode)
+
+			svc := service.NewPokerService(service.NewPokerServiceConfig())
+			_, err := Run(svc, agent)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Run() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewProcessAgentEmptyCommand(t *testing.T) {
+	if _, err := NewProcessAgent(nil, time.Second); err == nil {
+		t.Error("NewProcessAgent() with empty command should return error")
+	}
+}
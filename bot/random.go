@@ -0,0 +1,36 @@
+package bot
+
+import (
+	"math/rand"
+)
+
+type randomAgent struct {
+	rnd *rand.Rand
+}
+
+// NewRandomAgent returns an agent that plays or discards random cards.
+func NewRandomAgent(seed int64) Agent {
+	return &randomAgent{
+		rnd: rand.New(rand.NewSource(seed)), // #nosec G404 -- game play does not need secure randomness
+	}
+}
+
+func (a *randomAgent) Name() string {
+	return "random"
+}
+
+func (a *randomAgent) Act(obs Observation) (Action, error) {
+	actionType := ActionPlay
+	if obs.Discards > 0 && a.rnd.Intn(2) == 0 {
+		actionType = ActionDiscard
+	}
+
+	num := obs.MaxSelectCards
+	if len(obs.HandCards) < num {
+		num = len(obs.HandCards)
+	}
+	num = a.rnd.Intn(num) + 1
+
+	cards := a.rnd.Perm(len(obs.HandCards))[:num]
+	return Action{Type: actionType, Cards: cards}, nil
+}
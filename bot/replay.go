@@ -0,0 +1,64 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Replay is a recorded run that can be saved as JSON.
+type Replay struct {
+	StartedAt time.Time `json:"started_at"`
+	// Seed is the seed of the deck order, which replays the run with the
+	// same rules.
+	Seed int64 `json:"seed"`
+	// Rules is the name of the rules the run was played with.
+	Rules string `json:"rules,omitempty"`
+	Error string `json:"error,omitempty"`
+	*Result
+}
+
+// NewReplay returns a replay of the result. err is the error that ended the
+// run early, if any.
+func NewReplay(startedAt time.Time, result *Result, err error) *Replay {
+	replay := &Replay{StartedAt: startedAt, Result: result}
+	if err != nil {
+		replay.Error = err.Error()
+	}
+	return replay
+}
+
+// Save writes the replay into dir and returns the path of the file.
+func (r *Replay) Save(dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("%s-%s.json", r.StartedAt.Format("20060102-150405.000000000"), r.Agent)
+	path := filepath.Join(dir, name)
+
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// LoadReplay reads a replay saved by Save.
+func LoadReplay(path string) (*Replay, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- replay path is given by the user
+	if err != nil {
+		return nil, err
+	}
+
+	var replay Replay
+	if err := json.Unmarshal(data, &replay); err != nil {
+		return nil, fmt.Errorf("parse replay %s: %w", path, err)
+	}
+	return &replay, nil
+}
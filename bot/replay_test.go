@@ -0,0 +1,43 @@
+package bot
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReplaySaveAndLoad(t *testing.T) {
+	result := &Result{
+		Agent: "greedy",
+		Ante:  2,
+		Steps: []Step{
+			{Action: Action{Type: ActionDiscard, Cards: []int{0, 1}}},
+		},
+	}
+	replay := NewReplay(time.Now(), result, errors.New("agent crashed"))
+	replay.Seed = 42
+	replay.Rules = "Standard"
+
+	path, err := replay.Save(t.TempDir())
+	if err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	loaded, err := LoadReplay(path)
+	if err != nil {
+		t.Fatalf("LoadReplay() returned error: %v", err)
+	}
+
+	if loaded.Agent != "greedy" || loaded.Ante != 2 {
+		t.Errorf("LoadReplay() = %+v, want agent greedy at ante 2", loaded.Result)
+	}
+	if loaded.Seed != 42 || loaded.Rules != "Standard" {
+		t.Errorf("LoadReplay() Seed, Rules = %d, %q, want 42, Standard", loaded.Seed, loaded.Rules)
+	}
+	if loaded.Error != "agent crashed" {
+		t.Errorf("LoadReplay() Error = %q, want %q", loaded.Error, "agent crashed")
+	}
+	if len(loaded.Steps) != 1 || loaded.Steps[0].Action.Type != ActionDiscard {
+		t.Errorf("LoadReplay() Steps = %+v, want one discard", loaded.Steps)
+	}
+}
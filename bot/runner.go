@@ -0,0 +1,174 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/litencatt/pkr/entity"
+	"github.com/litencatt/pkr/service"
+)
+
+// ErrIllegalMove is returned when an agent chooses an action the rules do not allow.
+var ErrIllegalMove = errors.New("illegal move")
+
+// Step is an action taken by an agent and the state it was taken in.
+// Stats is set for played hands.
+type Step struct {
+	Observation Observation            `json:"observation"`
+	Action      Action                 `json:"action"`
+	Stats       *entity.PokerHandStats `json:"stats,omitempty"`
+}
+
+// Result is the outcome of a run played by an agent.
+type Result struct {
+	Agent string `json:"agent"`
+	Won   bool   `json:"won"`
+	// Ante is the last ante played, entity.WinAnte for a won run.
+	Ante     int    `json:"ante"`
+	Rounds   int    `json:"rounds"`
+	Discards int    `json:"discards"`
+	Steps    []Step `json:"steps"`
+}
+
+// PlayedHands returns the stats of every hand played during the run.
+func (r *Result) PlayedHands() []entity.PokerHandStats {
+	var hands []entity.PokerHandStats
+	for _, step := range r.Steps {
+		if step.Stats != nil {
+			hands = append(hands, *step.Stats)
+		}
+	}
+	return hands
+}
+
+// Run plays a full run with the agent until the run is won or lost.
+// The returned result holds the steps played so far even when an error
+// ends the run early.
+func Run(svc service.PokerService, agent Agent) (*Result, error) {
+	result := &Result{Agent: agent.Name()}
+	defer func() {
+		result.Ante = svc.GetAnte()
+		result.Rounds = svc.GetRounds()
+	}()
+
+	for {
+		if svc.IsStartRound() {
+			if err := svc.StartRound(); err != nil {
+				return result, err
+			}
+		}
+
+		if _, err := svc.DrawCard(svc.GetNextDrawNum()); err != nil {
+			return result, err
+		}
+
+		obs := Observe(svc)
+		action, err := agent.Act(obs)
+		if err != nil {
+			return result, err
+		}
+		result.Steps = append(result.Steps, Step{Observation: obs, Action: action})
+		if err := Validate(obs, action); err != nil {
+			return result, err
+		}
+
+		var selectCards []string
+		for _, i := range action.Cards {
+			selectCards = append(selectCards, obs.HandCards[i].String())
+		}
+		if err := svc.SelectCards(selectCards); err != nil {
+			return result, err
+		}
+
+		if action.Type == ActionDiscard {
+			svc.SetAction("Discard")
+			if err := svc.DiscardHand(); err != nil {
+				return result, err
+			}
+			result.Discards += len(action.Cards)
+			if svc.IsRoundLost() {
+				break
+			}
+			continue
+		}
+
+		svc.SetAction("Play")
+		stats, err := svc.PlayHand()
+		if err != nil {
+			return result, err
+		}
+		result.Steps[len(result.Steps)-1].Stats = &stats
+
+		if svc.IsRoundWin() {
+			if err := svc.NextRound(); err != nil {
+				return result, err
+			}
+			if svc.IsRunWon() {
+				result.Won = true
+				break
+			}
+			continue
+		}
+
+		if svc.IsRoundLost() {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// Observe returns the current state of the service as seen by an agent.
+func Observe(svc service.PokerService) Observation {
+	stats := svc.GetRoundStats()
+	return Observation{
+		HandCards:      append([]entity.Trump(nil), svc.GetHandCards()...),
+		Hands:          stats.Hands,
+		Discards:       stats.Discards,
+		TotalScore:     stats.TotalScore,
+		ScoreAtLeast:   stats.ScoreAtLeast,
+		DeckRemaining:  svc.GetDeckRemaining(),
+		Ante:           svc.GetAnte(),
+		Rounds:         svc.GetRounds(),
+		MaxSelectCards: svc.GetMaxSelectCards(),
+		PokerHands:     svc.GetPokerHands(),
+		Score: func(cards []entity.Trump) entity.PokerHandStats {
+			// A failing hook also fails the play, which reports the error
+			stats, _ := svc.ScoreHand(cards)
+			return stats
+		},
+	}
+}
+
+// Validate checks that the action is allowed in the observed state.
+func Validate(obs Observation, action Action) error {
+	switch action.Type {
+	case ActionPlay:
+		if obs.Hands <= 0 {
+			return fmt.Errorf("%w: no hands left", ErrIllegalMove)
+		}
+	case ActionDiscard:
+		if obs.Discards <= 0 {
+			return fmt.Errorf("%w: no discards left", ErrIllegalMove)
+		}
+	default:
+		return fmt.Errorf("%w: unknown action %q", ErrIllegalMove, action.Type)
+	}
+
+	if len(action.Cards) == 0 || len(action.Cards) > obs.MaxSelectCards {
+		return fmt.Errorf("%w: select 1 to %d cards, got %d", ErrIllegalMove, obs.MaxSelectCards, len(action.Cards))
+	}
+
+	seen := make(map[int]bool)
+	for _, i := range action.Cards {
+		if i < 0 || i >= len(obs.HandCards) {
+			return fmt.Errorf("%w: card index %d out of range", ErrIllegalMove, i)
+		}
+		if seen[i] {
+			return fmt.Errorf("%w: card index %d selected twice", ErrIllegalMove, i)
+		}
+		seen[i] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package bot
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/litencatt/pkr/entity"
+	"github.com/litencatt/pkr/service"
+)
+
+func TestRun(t *testing.T) {
+	for _, name := range AgentNames() {
+		t.Run(name, func(t *testing.T) {
+			agent, err := NewAgent(name, 1)
+			if err != nil {
+				t.Fatalf("NewAgent() returned error: %v", err)
+			}
+
+			svc := service.NewPokerService(service.NewPokerServiceConfig())
+			result, err := Run(svc, agent)
+			if err != nil {
+				t.Fatalf("Run() returned error: %v", err)
+			}
+
+			if result.Agent != name {
+				t.Errorf("Result.Agent = %s, want %s", result.Agent, name)
+			}
+			if len(result.PlayedHands()) == 0 {
+				t.Error("Result.PlayedHands() should not be empty")
+			}
+			if !result.Won && svc.GetRoundStats().Hands != 0 {
+				t.Error("A lost run should end with no hands left")
+			}
+		})
+	}
+}
+
+func TestNewAgentUnknown(t *testing.T) {
+	if _, err := NewAgent("unknown", 1); err == nil {
+		t.Error("NewAgent() with unknown name should return error")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	obs := Observation{
+		HandCards:      make([]entity.Trump, 8),
+		Hands:          1,
+		Discards:       0,
+		MaxSelectCards: entity.DefaultMaxSelectCards,
+	}
+
+	tests := []struct {
+		name    string
+		action  Action
+		illegal bool
+	}{
+		{"play", Action{Type: ActionPlay, Cards: []int{0, 1}}, false},
+		{"no discards left", Action{Type: ActionDiscard, Cards: []int{0}}, true},
+		{"unknown action", Action{Type: "fold", Cards: []int{0}}, true},
+		{"no cards", Action{Type: ActionPlay}, true},
+		{"too many cards", Action{Type: ActionPlay, Cards: []int{0, 1, 2, 3, 4, 5}}, true},
+		{"out of range", Action{Type: ActionPlay, Cards: []int{8}}, true},
+		{"duplicate", Action{Type: ActionPlay, Cards: []int{1, 1}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(obs, tt.action)
+			if got := errors.Is(err, ErrIllegalMove); got != tt.illegal {
+				t.Errorf("Validate() error = %v, want illegal %v", err, tt.illegal)
+			}
+		})
+	}
+}
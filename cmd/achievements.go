@@ -0,0 +1,61 @@
+/*
+Copyright © 2024 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/litencatt/pkr/achievement"
+	"github.com/litencatt/pkr/profile"
+	"github.com/spf13/cobra"
+)
+
+var achievementsCmd = &cobra.Command{
+	Use:          "achievements",
+	Short:        "Show your achievements and what they unlock",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := profile.Dir()
+		if err != nil {
+			return err
+		}
+		p, err := profile.Load(dir)
+		if err != nil {
+			return err
+		}
+		progress := p.Achievements
+
+		earned := 0
+		for _, a := range achievement.All() {
+			mark := "[ ]"
+			status := fmt.Sprintf("%d/%d", progress.Counts[a.ID], a.Goal)
+			if progress.IsEarned(a.ID) {
+				earned++
+				mark = "[x]"
+				status = progress.Unlocked[a.ID].Format("2006-01-02")
+			}
+
+			var unlocks []string
+			for _, u := range achievement.Unlocks() {
+				if u.Achievement == a.ID {
+					unlocks = append(unlocks, fmt.Sprintf("%s %s", u.Name, u.Kind))
+				}
+			}
+			line := fmt.Sprintf("%s %-14s %-36s %s", mark, a.Name, a.Description, status)
+			if len(unlocks) > 0 {
+				line += "  → unlocks " + strings.Join(unlocks, ", ")
+			}
+			fmt.Println(line)
+		}
+
+		fmt.Println()
+		fmt.Printf("Earned %d of %d achievements\n", earned, len(achievement.All()))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(achievementsCmd)
+}
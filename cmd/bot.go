@@ -0,0 +1,112 @@
+/*
+Copyright © 2024 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/litencatt/pkr/bot"
+	"github.com/litencatt/pkr/profile"
+	"github.com/litencatt/pkr/service"
+	"github.com/spf13/cobra"
+)
+
+var (
+	botAgent     string
+	botExec      string
+	botTimeout   time.Duration
+	botRuns      int
+	botReplayDir string
+)
+
+var botCmd = &cobra.Command{
+	Use:   "bot",
+	Short: "Play runs automatically with a bot agent",
+	// Errors come from the agent, not from the command line
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rules, err := loadRules()
+		if err != nil {
+			return err
+		}
+		if err := pickSeed(cmd); err != nil {
+			return err
+		}
+		replayDir := botReplayDir
+		if replayDir == "" {
+			dir, err := profile.Dir()
+			if err != nil {
+				return err
+			}
+			replayDir = filepath.Join(dir, "replays")
+		}
+
+		for i := 0; i < botRuns; i++ {
+			runSeed := seed + int64(i)
+			agent, err := newBotAgent(runSeed)
+			if err != nil {
+				return err
+			}
+
+			startedAt := time.Now()
+			svc := service.NewPokerService(service.PokerServiceConfig{Seed: &runSeed, Rules: rules})
+			result, runErr := bot.Run(svc, agent)
+			if closer, ok := agent.(io.Closer); ok {
+				_ = closer.Close()
+			}
+
+			replay := bot.NewReplay(startedAt, result, runErr)
+			replay.Seed = runSeed
+			replay.Rules = rules.Name
+			path, err := replay.Save(replayDir)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("replay saved: %s\n", path)
+			if runErr != nil {
+				return fmt.Errorf("run %d: %w", i+1, runErr)
+			}
+
+			status := "lost"
+			if result.Won {
+				status = "won"
+			}
+			hands := result.PlayedHands()
+			bestScore := 0
+			for _, hand := range hands {
+				if hand.Score > bestScore {
+					bestScore = hand.Score
+				}
+			}
+			fmt.Printf("run %d: %s  ante: %d  rounds: %d  hands: %d  best hand score: %d  seed: %d\n",
+				i+1, status, result.Ante, result.Rounds, len(hands), bestScore, runSeed)
+		}
+
+		return nil
+	},
+}
+
+func newBotAgent(seed int64) (bot.Agent, error) {
+	if botExec != "" {
+		return bot.NewProcessAgent(strings.Fields(botExec), botTimeout)
+	}
+	return bot.NewAgent(botAgent, seed)
+}
+
+func init() {
+	rootCmd.AddCommand(botCmd)
+
+	botCmd.Flags().StringVarP(&botAgent, "agent", "a", "greedy",
+		fmt.Sprintf("agent to play with (%s)", strings.Join(bot.AgentNames(), ", ")))
+	botCmd.Flags().StringVar(&botExec, "exec", "", "external agent command speaking the bot protocol on stdin/stdout")
+	botCmd.Flags().DurationVar(&botTimeout, "timeout", bot.DefaultMoveTimeout, "time an external agent may take per move")
+	botCmd.Flags().IntVarP(&botRuns, "runs", "n", 1, "number of runs to play")
+	botCmd.Flags().Int64Var(&seed, "seed", 0, "base seed, run i is played with seed+i")
+	botCmd.Flags().StringVar(&botReplayDir, "replay-dir", "", "directory to save run replays into (default is the replays directory of the profile)")
+	addRulesFlag(botCmd)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright © 2024 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/litencatt/pkr/profile"
+	"github.com/litencatt/pkr/service"
+	"github.com/spf13/cobra"
+)
+
+var challengeCmd = &cobra.Command{
+	Use:   "challenge",
+	Short: "Play runs with fixed starting conditions and rule changes",
+}
+
+var challengeListCmd = &cobra.Command{
+	Use:          "list",
+	Short:        "List the challenges and your progress",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := profile.Dir()
+		if err != nil {
+			return err
+		}
+		p, err := profile.Load(dir)
+		if err != nil {
+			return err
+		}
+
+		completed := 0
+		challenges := loadPacks().Challenges()
+		for _, c := range challenges {
+			mark := "[ ]"
+			status := "not played"
+			if progress := p.Challenges[c.ID]; progress != nil {
+				status = fmt.Sprintf("best ante %d in %d attempt(s)", progress.BestAnte, progress.Attempts)
+				if progress.Completed != nil {
+					completed++
+					mark = "[x]"
+					status = "completed " + progress.Completed.Format("2006-01-02")
+				}
+			}
+			fmt.Printf("%s %-20s %-14s %s\n", mark, c.ID, c.Name, status)
+			fmt.Printf("    %s\n", c.Description)
+		}
+
+		fmt.Println()
+		fmt.Printf("Completed %d of %d challenges\n", completed, len(challenges))
+		return nil
+	},
+}
+
+var challengeRunCmd = &cobra.Command{
+	Use:          "run <name>",
+	Short:        "Play a challenge",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		catalog := loadPacks()
+		challenge, err := catalog.FindChallenge(args[0])
+		if err != nil {
+			return err
+		}
+		if err := pickSeed(cmd); err != nil {
+			return err
+		}
+
+		config := service.PokerServiceConfig{
+			DebugMode:  true,
+			Seed:       &seed,
+			Challenge:  &challenge,
+			BossBlinds: catalog.BossBlinds(),
+		}
+		profileDir, err := profile.Dir()
+		if err != nil {
+			return err
+		}
+		p, err := profile.Load(profileDir)
+		if err != nil {
+			return err
+		}
+		if err := checkUnlocked(p.Achievements, challenge.Deck, challenge.Jokers); err != nil {
+			return err
+		}
+		return play(config, profileDir)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(challengeCmd)
+	challengeCmd.AddCommand(challengeListCmd)
+	challengeCmd.AddCommand(challengeRunCmd)
+
+	challengeRunCmd.Flags().BoolVarP(&debugMode, "debug", "d", false, "show detail logs")
+	challengeRunCmd.Flags().BoolVar(&hints, "hints", false, "show the best play and the chance to clear the round")
+	challengeRunCmd.Flags().StringVar(&ui, "ui", "prompt", "user interface (prompt, tui)")
+	challengeRunCmd.Flags().Int64Var(&seed, "seed", 0, "seed for a reproducible deck order")
+}
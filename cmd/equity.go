@@ -0,0 +1,92 @@
+/*
+Copyright © 2024 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"strings"
+
+	"github.com/litencatt/pkr/entity"
+	"github.com/litencatt/pkr/equity"
+	"github.com/spf13/cobra"
+)
+
+var (
+	equityConfig equity.Config
+	equityBoard  string
+	equityExact  bool
+)
+
+var equityCmd = &cobra.Command{
+	Use:   "equity HAND HAND...",
+	Short: "Compute how often hold'em hands win against each other",
+	Example: `  pkr equity AhKh QsQd --board 2h7h9c
+  pkr equity "As Ad" "Kc Kd" "7h 8h"`,
+	Args:         cobra.MinimumNArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := pickSeed(cmd); err != nil {
+			return err
+		}
+		cfg := equityConfig
+		cfg.Seed = seed
+		if equityExact {
+			cfg.MaxBoards = math.MaxInt
+		}
+
+		var err error
+		if cfg.Board, err = entity.ParseCards(equityBoard); err != nil {
+			return fmt.Errorf("board: %w", err)
+		}
+		for _, arg := range args {
+			hand, err := entity.ParseCards(arg)
+			if err != nil {
+				return err
+			}
+			cfg.Hands = append(cfg.Hands, hand)
+		}
+
+		result, err := equity.Calculate(cfg)
+		if err != nil {
+			return err
+		}
+
+		if len(cfg.Board) > 0 {
+			fmt.Printf("Board: %s\n", notation(cfg.Board))
+		}
+		if result.Exhaustive {
+			fmt.Printf("Exhaustive: %d boards\n", result.Boards)
+		} else {
+			fmt.Printf("Monte Carlo: %d boards  |  Seed: %d\n", result.Boards, cfg.Seed)
+		}
+		fmt.Println()
+
+		fmt.Printf("  %-8s %8s %8s %8s\n", "Hand", "Win", "Tie", "Equity")
+		for i, hand := range result.Hands {
+			fmt.Printf("  %-8s %7.2f%% %7.2f%% %7.2f%%\n",
+				notation(hand.Cards), result.Win(i)*100, result.Tie(i)*100, result.Equity(i)*100)
+		}
+		return nil
+	},
+}
+
+func notation(cards []entity.Trump) string {
+	names := make([]string, len(cards))
+	for i, card := range cards {
+		names[i] = card.Notation()
+	}
+	return strings.Join(names, " ")
+}
+
+func init() {
+	rootCmd.AddCommand(equityCmd)
+
+	equityCmd.Flags().StringVarP(&equityBoard, "board", "b", "", "community cards already dealt, like 2h7h9c")
+	equityCmd.Flags().IntVarP(&equityConfig.Trials, "trials", "n", 100000, "number of random boards when there are too many to enumerate")
+	equityCmd.Flags().BoolVar(&equityExact, "exact", false, "enumerate every board however many there are")
+	equityCmd.Flags().IntVarP(&equityConfig.Workers, "workers", "w", runtime.NumCPU(), "number of concurrent workers")
+	equityCmd.Flags().Int64Var(&seed, "seed", 0, "seed of the random boards, to repeat a Monte Carlo result")
+}
@@ -0,0 +1,39 @@
+/*
+Copyright © 2024 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"github.com/litencatt/pkr"
+	"github.com/spf13/cobra"
+)
+
+var holdemConfig pkr.HoldemConfig
+
+var holdemCmd = &cobra.Command{
+	Use:          "holdem",
+	Short:        "Play no-limit Texas Hold'em against computer opponents",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := pickSeed(cmd); err != nil {
+			return err
+		}
+		holdemConfig.Seed = seed
+
+		poker, err := pkr.NewHoldemCLI(holdemConfig)
+		if err != nil {
+			return err
+		}
+		return poker.Run()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(holdemCmd)
+
+	holdemCmd.Flags().IntVarP(&holdemConfig.Players, "players", "p", 2, "players at the table including you (2-6)")
+	holdemCmd.Flags().IntVar(&holdemConfig.Stack, "stack", 1000, "starting chips of every player")
+	holdemCmd.Flags().IntVar(&holdemConfig.SmallBlind, "small-blind", 5, "small blind")
+	holdemCmd.Flags().IntVar(&holdemConfig.BigBlind, "big-blind", 10, "big blind")
+	holdemCmd.Flags().Int64Var(&seed, "seed", 0, "seed for reproducible deals and opponents")
+}
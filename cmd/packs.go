@@ -0,0 +1,76 @@
+/*
+Copyright © 2024 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/litencatt/pkr/pack"
+	"github.com/spf13/cobra"
+)
+
+var packsCmd = &cobra.Command{
+	Use:          "packs",
+	Short:        "List the content packs in ~/.pkr/packs",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := pack.Dir()
+		if err != nil {
+			return err
+		}
+		catalog, errs := pack.LoadDir(dir)
+
+		if len(catalog.Packs) == 0 && len(errs) == 0 {
+			fmt.Printf("No packs in %s\n", dir)
+			return nil
+		}
+		for _, p := range catalog.Packs {
+			fmt.Printf("📦 %s  (%s)\n", p.Name, p.Path)
+			if p.Description != "" {
+				fmt.Printf("  %s\n", p.Description)
+			}
+			for _, j := range p.Jokers {
+				fmt.Printf("  joker      %-24s %s\n", p.ID(j.ID), j.Name)
+			}
+			for _, d := range p.Decks {
+				fmt.Printf("  deck       %-24s %s\n", p.ID(d.ID), d.Name)
+			}
+			for _, b := range p.BossBlinds {
+				fmt.Printf("  boss blind %-24s %s\n", p.ID(b.ID), b.Name)
+			}
+			for _, c := range p.Challenges {
+				fmt.Printf("  challenge  %-24s %s\n", p.ID(c.ID), c.Name)
+			}
+			fmt.Println()
+		}
+
+		for _, err := range errs {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		}
+		if len(errs) > 0 {
+			return fmt.Errorf("%d invalid pack(s)", len(errs))
+		}
+		return nil
+	},
+}
+
+// loadPacks returns the valid packs. Invalid packs are reported on stderr
+// and skipped so that one broken file does not stop the game.
+func loadPacks() *pack.Catalog {
+	dir, err := pack.Dir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		return &pack.Catalog{}
+	}
+	catalog, errs := pack.LoadDir(dir)
+	for _, err := range errs {
+		fmt.Fprintf(os.Stderr, "warning: skipping invalid pack %v\n", err)
+	}
+	return catalog
+}
+
+func init() {
+	rootCmd.AddCommand(packsCmd)
+}
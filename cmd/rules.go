@@ -0,0 +1,45 @@
+/*
+Copyright © 2024 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"github.com/litencatt/pkr/entity"
+	"github.com/litencatt/pkr/rules"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var rulesFile string
+
+// addRulesFlag adds the --rules flag to a command that plays runs.
+func addRulesFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&rulesFile, "rules", "", "rules file (default is the rules section of the config file)")
+}
+
+// rulesPath returns the file the rules are read from, or "" for the
+// standard rules.
+func rulesPath() string {
+	if rulesFile != "" {
+		return rulesFile
+	}
+	if viper.IsSet(rules.Key) {
+		return viper.ConfigFileUsed()
+	}
+	return ""
+}
+
+// loadRules returns the rules from --rules, or from the config file.
+func loadRules() (*entity.Rules, error) {
+	var r entity.Rules
+	var err error
+	if rulesFile != "" {
+		r, err = rules.LoadFile(rulesFile)
+	} else {
+		r, err = rules.Load(viper.GetViper())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
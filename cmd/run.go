@@ -4,31 +4,166 @@ Copyright © 2024 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"crypto/rand"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+
 	"github.com/litencatt/pkr"
+	"github.com/litencatt/pkr/achievement"
+	"github.com/litencatt/pkr/entity"
+	"github.com/litencatt/pkr/profile"
+	"github.com/litencatt/pkr/service"
 	"github.com/spf13/cobra"
 )
 
-var debugMode bool
+var (
+	debugMode bool
+	hints     bool
+	ui        string
+	script    string
+	seed      int64
+	deckName  string
+	stakeName string
+	jokerIDs  []string
+)
 
 var runCmd = &cobra.Command{
 	Use:   "run",
 	Short: "Run poker",
+	// Errors come from the game, not from the command line
+	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		poker := pkr.NewPokerCLI()
-		if debugMode {
-			poker.DebugMode = true
+		config := service.PokerServiceConfig{
+			DebugMode: true,
+		}
+		if err := pickSeed(cmd); err != nil {
+			return err
+		}
+		config.Seed = &seed
+		catalog := loadPacks()
+		deck, err := catalog.FindDeck(deckName)
+		if err != nil {
+			return err
+		}
+		config.Deck = &deck
+		config.BossBlinds = catalog.BossBlinds()
+		for _, id := range jokerIDs {
+			joker, err := catalog.FindJoker(id)
+			if err != nil {
+				return err
+			}
+			config.Jokers = append(config.Jokers, joker)
+		}
+		stake, err := entity.FindStake(stakeName)
+		if err != nil {
+			return err
+		}
+		config.Stake = &stake
+		config.Rules, err = loadRules()
+		if err != nil {
+			return err
 		}
 
-		if err := poker.Run(); err != nil {
+		// Locked content cannot be played with a script either
+		profileDir, err := profile.Dir()
+		if err != nil {
+			return err
+		}
+		p, err := profile.Load(profileDir)
+		if err != nil {
+			return err
+		}
+		if a, locked := p.Achievements.Locked("stake", stake.Name); locked {
+			return fmt.Errorf("the %s stake is locked: earn %q (%s) to unlock it", stake.Name, a.Name, a.Description)
+		}
+		if err := checkUnlocked(p.Achievements, deck, config.Jokers); err != nil {
 			return err
 		}
 
-		return nil
+		if script != "" {
+			f, err := os.Open(script) // #nosec G304 -- script path is given by the user
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			return pkr.NewPokerScript(f, os.Stdout, config).Run()
+		}
+
+		return play(config, profileDir)
 	},
 }
 
+// checkUnlocked returns an error if the deck, its jokers or the other
+// jokers are locked behind an achievement that is not earned yet.
+func checkUnlocked(progress achievement.Progress, deck entity.StartingDeck, jokers []entity.Joker) error {
+	if a, locked := progress.Locked("deck", deck.Name); locked {
+		return fmt.Errorf("the %s deck is locked: earn %q (%s) to unlock it", deck.Name, a.Name, a.Description)
+	}
+	for _, joker := range append(append([]entity.Joker(nil), deck.Jokers...), jokers...) {
+		a, locked := progress.Locked("joker", joker.ID)
+		if !locked {
+			a, locked = progress.Requires(joker.UnlockedBy)
+		}
+		if locked {
+			return fmt.Errorf("the %s joker is locked: earn %q (%s) to unlock it", joker.Name, a.Name, a.Description)
+		}
+	}
+	return nil
+}
+
+// pickSeed picks a random seed unless --seed is given, so that every run
+// can be replayed.
+func pickSeed(cmd *cobra.Command) error {
+	if cmd.Flags().Changed("seed") {
+		return nil
+	}
+	n, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
+	if err != nil {
+		return err
+	}
+	seed = n.Int64()
+	return nil
+}
+
+// play runs the game with the interface chosen by --ui.
+func play(config service.PokerServiceConfig, profileDir string) error {
+	switch ui {
+	case "prompt":
+		poker := pkr.NewPokerCLI(config)
+		if debugMode {
+			poker.DebugMode = true
+		}
+		poker.Hints = hints
+		poker.ProfileDir = profileDir
+		poker.RulesFile = rulesPath()
+
+		return poker.Run()
+	case "tui":
+		poker := pkr.NewPokerTUI(config)
+		poker.DebugMode = debugMode
+		poker.Hints = hints
+		poker.ProfileDir = profileDir
+		poker.RulesFile = rulesPath()
+
+		return poker.Run()
+	default:
+		return fmt.Errorf("unknown ui %q (available: prompt, tui)", ui)
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(runCmd)
 
 	runCmd.Flags().BoolVarP(&debugMode, "debug", "d", false, "show detail logs")
+	runCmd.Flags().BoolVar(&hints, "hints", false, "show the best play and the chance to clear the round")
+	runCmd.Flags().StringVar(&ui, "ui", "prompt", "user interface (prompt, tui)")
+	runCmd.Flags().StringVar(&script, "script", "", "play the moves in a script file without prompts")
+	runCmd.Flags().Int64Var(&seed, "seed", 0, "seed for a reproducible deck order")
+	runCmd.Flags().StringVar(&deckName, "deck", entity.StandardDeck().Name, "starting deck (Standard, Red, Blue, Abandoned, or pack:deck)")
+	runCmd.Flags().StringVar(&stakeName, "stake", entity.WhiteStake().Name, "stake to play at (White, Red, Green, Gold)")
+	runCmd.Flags().StringSliceVar(&jokerIDs, "joker", nil, "start with a joker from a content pack (pack:joker)")
+	addRulesFlag(runCmd)
 }
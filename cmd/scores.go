@@ -0,0 +1,62 @@
+/*
+Copyright © 2024 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/litencatt/pkr/profile"
+	"github.com/spf13/cobra"
+)
+
+var (
+	scoresFilter profile.ScoreFilter
+	scoresLimit  int
+)
+
+var scoresCmd = &cobra.Command{
+	Use:          "scores",
+	Short:        "Show the top runs",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := profile.Dir()
+		if err != nil {
+			return err
+		}
+		scores, err := profile.LoadScores(dir)
+		if err != nil {
+			return err
+		}
+
+		top := profile.TopScores(scores, scoresFilter, scoresLimit)
+		if len(top) == 0 {
+			fmt.Println("No runs recorded yet. Start one with `pkr run`.")
+			return nil
+		}
+
+		fmt.Printf("%-3s %-5s %-9s %-20s %-10s %-7s %-12s %-16s %s\n", "#", "Ante", "Best Hand", "Seed", "Deck", "Stake", "Rules", "Date", "Replay")
+		for i, s := range top {
+			seed := "-"
+			if s.Seed != nil {
+				seed = fmt.Sprint(*s.Seed)
+			}
+			ante := fmt.Sprint(s.Ante)
+			if s.Won {
+				ante += "★"
+			}
+			fmt.Printf("%-3d %-5s %-9d %-20s %-10s %-7s %-12s %-16s %s\n",
+				i+1, ante, s.BestHand, seed, s.Deck, s.Stake, s.RulesName(), s.Date.Format("2006-01-02 15:04"), s.Replay)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(scoresCmd)
+
+	scoresCmd.Flags().StringVar(&scoresFilter.Deck, "deck", "", "only show runs with this deck")
+	scoresCmd.Flags().StringVar(&scoresFilter.Stake, "stake", "", "only show runs at this stake")
+	scoresCmd.Flags().StringVar(&scoresFilter.Rules, "rules", "", "only show runs with this ruleset name")
+	scoresCmd.Flags().IntVarP(&scoresLimit, "limit", "n", 10, "number of runs to show")
+}
@@ -0,0 +1,67 @@
+/*
+Copyright © 2024 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/litencatt/pkr/bot"
+	"github.com/litencatt/pkr/sim"
+	"github.com/spf13/cobra"
+)
+
+var simConfig sim.Config
+
+var simCmd = &cobra.Command{
+	Use:   "sim",
+	Short: "Simulate many seeded runs with a bot agent",
+	// Errors come from the rules or the simulation, not from the command line
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var err error
+		simConfig.Rules, err = loadRules()
+		if err != nil {
+			return err
+		}
+
+		report, err := sim.Run(simConfig)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Runs: %d  |  Agent: %s  |  Seed: %d  |  Rules: %s\n", report.Runs, simConfig.Agent, simConfig.Seed, simConfig.Rules.Name)
+		fmt.Printf("Win rate: %.2f%% (%d/%d)\n", report.WinRate()*100, report.Wins, report.Runs)
+		fmt.Println()
+
+		fmt.Println("────────── Ante Reached ──────────")
+		for _, ante := range report.SortedAntes() {
+			count := report.Antes[ante]
+			fmt.Printf("  Ante %-3d %8d  %6.2f%%\n", ante, count, float64(count)/float64(report.Runs)*100)
+		}
+		fmt.Println()
+
+		fmt.Println("────────── Hand Types ──────────")
+		fmt.Printf("  %-16s %8s %8s %10s\n", "Hand", "Played", "Rate", "Avg Score")
+		for _, handType := range report.SortedHandTypes() {
+			stats := report.HandTypes[handType]
+			fmt.Printf("  %-16s %8d %7.2f%% %10.1f\n",
+				handType, stats.Played, float64(stats.Played)/float64(report.HandsPlayed)*100, stats.AverageScore())
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(simCmd)
+
+	simCmd.Flags().IntVarP(&simConfig.Runs, "runs", "n", 1000, "number of runs to simulate")
+	simCmd.Flags().StringVarP(&simConfig.Agent, "agent", "a", "greedy",
+		fmt.Sprintf("agent to play with (%s)", strings.Join(bot.AgentNames(), ", ")))
+	simCmd.Flags().IntVarP(&simConfig.Workers, "workers", "w", runtime.NumCPU(), "number of concurrent workers")
+	simCmd.Flags().Int64Var(&simConfig.Seed, "seed", 1, "base seed, run i is played with seed+i")
+	addRulesFlag(simCmd)
+}
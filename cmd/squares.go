@@ -0,0 +1,35 @@
+/*
+Copyright © 2024 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"github.com/litencatt/pkr"
+	"github.com/spf13/cobra"
+)
+
+var squaresScoring string
+
+var squaresCmd = &cobra.Command{
+	Use:          "squares",
+	Short:        "Play Poker Squares, placing 25 cards on a 5x5 grid",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := pickSeed(cmd); err != nil {
+			return err
+		}
+
+		poker, err := pkr.NewSquaresCLI(squaresScoring, seed)
+		if err != nil {
+			return err
+		}
+		return poker.Run()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(squaresCmd)
+
+	squaresCmd.Flags().StringVar(&squaresScoring, "scoring", "american", "scoring table (american, british)")
+	squaresCmd.Flags().Int64Var(&seed, "seed", 0, "seed of the deal, to compare scores on the same deal")
+}
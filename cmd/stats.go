@@ -0,0 +1,95 @@
+/*
+Copyright © 2024 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/litencatt/pkr/profile"
+	"github.com/spf13/cobra"
+)
+
+var statsJSON bool
+
+var statsCmd = &cobra.Command{
+	Use:          "stats",
+	Short:        "Show the statistics of your past runs",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := profile.Dir()
+		if err != nil {
+			return err
+		}
+		p, err := profile.Load(dir)
+		if err != nil {
+			return err
+		}
+
+		if statsJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(p)
+		}
+
+		if p.RunsPlayed == 0 && len(p.VideoPoker) == 0 {
+			fmt.Println("No runs played yet. Start one with `pkr run`.")
+			return nil
+		}
+
+		if p.RunsPlayed > 0 {
+			printRunStats(p)
+		}
+		if len(p.VideoPoker) > 0 {
+			printVideoPokerStats(p)
+		}
+		return nil
+	},
+}
+
+func printRunStats(p *profile.Profile) {
+	fmt.Printf("Runs played:        %d\n", p.RunsPlayed)
+	fmt.Printf("Runs won:           %d (%.1f%%)\n", p.RunsWon, float64(p.RunsWon)/float64(p.RunsPlayed)*100)
+	fmt.Printf("Best ante:          %d\n", p.BestAnte)
+	fmt.Printf("Highest hand score: %d\n", p.HighestHandScore)
+	fmt.Printf("Favorite deck:      %s\n", p.FavoriteDeck)
+	fmt.Printf("Cards discarded:    %d\n", p.CardsDiscarded)
+	fmt.Printf("Runs with hints:    %d\n", p.HintedRuns)
+	fmt.Println()
+
+	fmt.Println("────────── Hand Types ──────────")
+	for _, handType := range p.SortedHandTypes() {
+		fmt.Printf("  %-16s %8d\n", handType, p.HandTypes[handType])
+	}
+}
+
+func printVideoPokerStats(p *profile.Profile) {
+	var keys []string
+	for key := range p.VideoPoker {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fmt.Println()
+	fmt.Println("────────── Video Poker ──────────")
+	for _, key := range keys {
+		s := p.VideoPoker[key]
+		fmt.Printf("  %s\n", key)
+		fmt.Printf("    Sessions: %d  Hands: %d  Best win: %d\n", s.Sessions, s.Hands, s.BestWin)
+		if s.Wagered > 0 {
+			fmt.Printf("    Wagered: %d  Won: %d  Return: %.1f%%\n", s.Wagered, s.Won, float64(s.Won)/float64(s.Wagered)*100)
+		}
+		if s.DoubleUpsWon+s.DoubleUpsLost > 0 {
+			fmt.Printf("    Double-ups won: %d  lost: %d\n", s.DoubleUpsWon, s.DoubleUpsLost)
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+
+	statsCmd.Flags().BoolVar(&statsJSON, "json", false, "print the statistics as JSON")
+}
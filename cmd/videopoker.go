@@ -0,0 +1,67 @@
+/*
+Copyright © 2024 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/litencatt/pkr"
+	"github.com/litencatt/pkr/profile"
+	"github.com/litencatt/pkr/videopoker"
+	"github.com/spf13/cobra"
+)
+
+var videoPokerConfig pkr.VideoPokerConfig
+
+var videoPokerCmd = &cobra.Command{
+	Use:          "videopoker",
+	Short:        "Play video poker machines with paytables and a double-up",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := pickSeed(cmd); err != nil {
+			return err
+		}
+		videoPokerConfig.Seed = seed
+
+		poker, err := pkr.NewVideoPokerCLI(videoPokerConfig)
+		if err != nil {
+			return err
+		}
+		dir, err := profile.Dir()
+		if err != nil {
+			return err
+		}
+		poker.ProfileDir = dir
+		return poker.Run()
+	},
+}
+
+var videoPokerListCmd = &cobra.Command{
+	Use:          "list",
+	Short:        "List the machines and their paytables",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, m := range videopoker.Machines() {
+			var paytables []string
+			for _, p := range m.Paytables {
+				paytables = append(paytables, p.Name)
+			}
+			fmt.Printf("%-8s %-16s paytables: %s\n", m.ID, m.Name, strings.Join(paytables, ", "))
+			fmt.Printf("    %s\n", m.Description)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(videoPokerCmd)
+	videoPokerCmd.AddCommand(videoPokerListCmd)
+
+	videoPokerCmd.Flags().StringVarP(&videoPokerConfig.Machine, "machine", "m", "jacks", "machine ID or name (run \"pkr videopoker list\" for all)")
+	videoPokerCmd.Flags().StringVar(&videoPokerConfig.Paytable, "paytable", "", "paytable of the machine (default: its best paying)")
+	videoPokerCmd.Flags().IntVar(&videoPokerConfig.Credits, "credits", 100, "starting credits")
+	videoPokerCmd.Flags().BoolVar(&videoPokerConfig.DoubleUp, "double-up", false, "offer a double-or-nothing gamble after every win")
+	videoPokerCmd.Flags().Int64Var(&seed, "seed", 0, "seed for reproducible deals")
+}
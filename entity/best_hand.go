@@ -0,0 +1,81 @@
+package entity
+
+import "fmt"
+
+// BestHand is the strongest five-card hand that can be made from more
+// cards.
+type BestHand struct {
+	// Cards are the five cards that form the hand, in the order they were
+	// given.
+	Cards    []Trump
+	Strength HandStrength
+}
+
+// BestFive returns the strongest five-card hand among the cards, e.g. the
+// 7 cards of Hold'em. Ties keep the first combination found.
+func BestFive(cards []Trump) (BestHand, error) {
+	if len(cards) < 5 {
+		return BestHand{}, fmt.Errorf("need at least 5 cards, got %d", len(cards))
+	}
+
+	var best BestHand
+	hand := make([]Trump, 5)
+	combinations(len(cards), 5, func(indexes []int) {
+		for i, index := range indexes {
+			hand[i] = cards[index]
+		}
+		consider(&best, hand)
+	})
+	return best, nil
+}
+
+// BestOmaha returns the strongest Omaha hand, which uses exactly two of the
+// hole cards and three of the board cards.
+func BestOmaha(hole, board []Trump) (BestHand, error) {
+	if len(hole) < 2 {
+		return BestHand{}, fmt.Errorf("need at least 2 hole cards, got %d", len(hole))
+	}
+	if len(board) < 3 {
+		return BestHand{}, fmt.Errorf("need at least 3 board cards, got %d", len(board))
+	}
+
+	var best BestHand
+	hand := make([]Trump, 5)
+	combinations(len(hole), 2, func(holeIndexes []int) {
+		combinations(len(board), 3, func(boardIndexes []int) {
+			hand[0], hand[1] = hole[holeIndexes[0]], hole[holeIndexes[1]]
+			for i, index := range boardIndexes {
+				hand[2+i] = board[index]
+			}
+			consider(&best, hand)
+		})
+	})
+	return best, nil
+}
+
+// consider replaces best with a copy of hand if hand is stronger.
+func consider(best *BestHand, hand []Trump) {
+	strength := EvaluateStrength(hand)
+	if best.Cards == nil || strength.Compare(best.Strength) > 0 {
+		best.Cards = append(best.Cards[:0], hand...)
+		best.Strength = strength
+	}
+}
+
+// combinations calls fn with every k-combination of the indexes 0 to n-1 in
+// lexicographic order. fn must not keep the slice.
+func combinations(n, k int, fn func(indexes []int)) {
+	indexes := make([]int, k)
+	var walk func(start, depth int)
+	walk = func(start, depth int) {
+		if depth == k {
+			fn(indexes)
+			return
+		}
+		for i := start; i <= n-(k-depth); i++ {
+			indexes[depth] = i
+			walk(i+1, depth+1)
+		}
+	}
+	walk(0, 0)
+}
@@ -0,0 +1,73 @@
+package entity
+
+import "testing"
+
+func TestBestFive(t *testing.T) {
+	tests := []struct {
+		name  string
+		cards string
+		want  string
+		best  string
+	}{
+		{"flush over straight", "9h 8h 7c 6h 5d 2h Kh", "Flush (K 9 8 6 2)", "9h 8h 6h 2h Kh"},
+		{"highest straight", "Ac 2d 3h 4s 5c 6d Kc", "Straight (6)", "2d 3h 4s 5c 6d"},
+		{"wheel", "Ac 2d 3h 4s 5c Jd Kc", "Straight (5)", "Ac 2d 3h 4s 5c"},
+		{"best two pairs and kicker", "Ac Ad Kc Kd Qc Qd 2h", "Two Pair (A K Q)", "Ac Ad Kc Kd Qc"},
+		{"full house from two trips", "9c 9d 9h 4c 4d 4h 2s", "Full House (9 4)", "9c 9d 9h 4c 4d"},
+		{"straight flush in 9 cards", "2s 3s 4s 5s 6s 7s Ah Ad Ac", "Straight Flush (7)", "3s 4s 5s 6s 7s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			best, err := BestFive(cards(t, tt.cards))
+			if err != nil {
+				t.Fatalf("BestFive() returned error: %v", err)
+			}
+			if got := best.Strength.String(); got != tt.want {
+				t.Errorf("Strength = %s, want %s", got, tt.want)
+			}
+			want := cards(t, tt.best)
+			for i := range want {
+				if best.Cards[i] != want[i] {
+					t.Errorf("Cards = %v, want %v", best.Cards, want)
+					break
+				}
+			}
+		})
+	}
+
+	if _, err := BestFive(cards(t, "Ac Kc Qc Jc")); err == nil {
+		t.Error("BestFive() of 4 cards should return error")
+	}
+}
+
+func TestBestOmaha(t *testing.T) {
+	tests := []struct {
+		name        string
+		hole, board string
+		want        string
+	}{
+		// Four Hearts on the board need two Hearts in the hand
+		{"no flush with one suited hole card", "Ah Kc Qd Js", "2h 5h 8h 9h Td", "Straight (Q)"},
+		{"flush with two suited hole cards", "Ah Kh Qd Js", "2h 5h 8h 9c Td", "Flush (A K 8 5 2)"},
+		// Only two of the hole Aces can be used
+		{"two hole cards only", "Ac Ad Ah 2c", "Kc Kd 7s", "Two Pair (A K 7)"},
+		{"three board cards only", "2c 3d 4h 5s", "Ac Ad Ah As Kc", "Three of a Kind (A 5 4)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			best, err := BestOmaha(cards(t, tt.hole), cards(t, tt.board))
+			if err != nil {
+				t.Fatalf("BestOmaha() returned error: %v", err)
+			}
+			if got := best.Strength.String(); got != tt.want {
+				t.Errorf("Strength = %s (%v), want %s", got, best.Cards, tt.want)
+			}
+		})
+	}
+
+	if _, err := BestOmaha(cards(t, "Ac"), cards(t, "Kc Qc Jc")); err == nil {
+		t.Error("BestOmaha() with 1 hole card should return error")
+	}
+}
@@ -0,0 +1,23 @@
+package entity
+
+// BossBlind is played as the last blind of an ante instead of a normal one
+// and makes it harder to clear.
+type BossBlind struct {
+	ID          string
+	Name        string
+	Description string
+	// ScoreMulti multiplies the score required to clear the blind when it is
+	// not zero.
+	ScoreMulti    float64
+	ExtraHands    int
+	ExtraDiscards int
+	// Debuff matches cards that score no chips and trigger no jokers.
+	Debuff *CardCondition
+	// Hooks are called after the jokers, if set.
+	Hooks Hooks
+}
+
+// IsDebuffed reports whether the boss blind debuffs the card.
+func (b *BossBlind) IsDebuffed(card Trump) bool {
+	return b != nil && b.Debuff != nil && b.Debuff.Match(card)
+}
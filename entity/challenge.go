@@ -0,0 +1,28 @@
+package entity
+
+// Challenge is a run with fixed starting conditions and rule changes.
+type Challenge struct {
+	ID          string
+	Name        string
+	Description string
+	Deck        StartingDeck
+	Jokers      []Joker
+	// Rules replace the standard rules when set.
+	Rules *Rules
+	// OnlyHands are the only hand types that score, if set.
+	OnlyHands []HandType
+}
+
+// Scores reports whether hands of the hand type score in the challenge.
+// Every hand type scores outside of a challenge.
+func (c *Challenge) Scores(handType HandType) bool {
+	if c == nil || len(c.OnlyHands) == 0 {
+		return true
+	}
+	for _, h := range c.OnlyHands {
+		if h == handType {
+			return true
+		}
+	}
+	return false
+}
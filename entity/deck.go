@@ -3,6 +3,7 @@ package entity
 import (
 	"crypto/rand"
 	"math/big"
+	mathrand "math/rand"
 )
 
 type Deck []Trump
@@ -35,7 +36,20 @@ func (d Deck) Shuffle() {
 	}
 }
 
+// ShuffleWith shuffles the deck with the given source, so that the same seed
+// always gives the same order.
+func (d Deck) ShuffleWith(rnd *mathrand.Rand) {
+	rnd.Shuffle(len(d), func(i, j int) {
+		d[i], d[j] = d[j], d[i]
+	})
+}
+
+// Draw takes n cards from the top of the deck, or all cards if fewer are
+// left.
 func (d *Deck) Draw(n int) []Trump {
+	if n > len(*d) {
+		n = len(*d)
+	}
 	hand := (*d)[:n]
 	*d = (*d)[n:]
 	return hand
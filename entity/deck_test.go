@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"math/rand"
 	"testing"
 )
 
@@ -111,6 +112,13 @@ func TestDeckDraw(t *testing.T) {
 	if len(deck) != 7 {
 		t.Errorf("After drawing 45 cards total, deck has %d cards, want 7", len(deck))
 	}
+
+	// Test drawing more cards than left
+	drawn4 := deck.Draw(8)
+
+	if len(drawn4) != 7 || len(deck) != 0 {
+		t.Errorf("Draw(8) with 7 cards left returned %d cards and left %d, want 7 and 0", len(drawn4), len(deck))
+	}
 }
 
 func TestDeckLen(t *testing.T) {
@@ -127,3 +135,60 @@ func TestDeckLen(t *testing.T) {
 		t.Errorf("After drawing 5 cards, deck.Len() = %d, want 47", deck.Len())
 	}
 }
+
+func TestDeckShuffleWith(t *testing.T) {
+	deck1 := NewDeck()
+	deck2 := NewDeck()
+
+	deck1.ShuffleWith(rand.New(rand.NewSource(42)))
+	deck2.ShuffleWith(rand.New(rand.NewSource(42)))
+
+	for i := range deck1 {
+		if deck1[i] != deck2[i] {
+			t.Fatalf("Decks shuffled with the same seed differ at %d: %s != %s", i, deck1[i], deck2[i])
+		}
+	}
+}
+
+func TestStartingDecks(t *testing.T) {
+	for _, deck := range StartingDecks() {
+		found, err := FindStartingDeck(deck.Name)
+		if err != nil {
+			t.Errorf("FindStartingDeck(%q) returned error: %v", deck.Name, err)
+		}
+		if found.Name != deck.Name {
+			t.Errorf("FindStartingDeck(%q) = %q", deck.Name, found.Name)
+		}
+	}
+
+	if _, err := FindStartingDeck("red"); err != nil {
+		t.Errorf("FindStartingDeck() should ignore case: %v", err)
+	}
+	if _, err := FindStartingDeck("Rainbow"); err == nil {
+		t.Error("FindStartingDeck() of an unknown deck should return error")
+	}
+
+	abandoned, _ := FindStartingDeck("Abandoned")
+	cards := abandoned.NewDeck()
+	if cards.Len() != 40 {
+		t.Errorf("Abandoned deck has %d cards, want 40", cards.Len())
+	}
+	// NewDeck returns a copy that can be shuffled
+	cards.ShuffleWith(rand.New(rand.NewSource(1)))
+	if abandoned.NewDeck()[0] != abandoned.Cards[0] {
+		t.Error("Shuffling the new deck should not change the starting deck")
+	}
+}
+
+func TestRunInfoUseDeck(t *testing.T) {
+	r := NewRunInfo()
+	red, _ := FindStartingDeck("Red")
+	r.UseDeck(red)
+
+	if r.DefaultDiscards != 4 || r.DefaultHands != 4 {
+		t.Errorf("Hands, Discards = %d, %d, want 4, 4", r.DefaultHands, r.DefaultDiscards)
+	}
+	if r.DeckName != "Red" {
+		t.Errorf("DeckName = %q, want Red", r.DeckName)
+	}
+}
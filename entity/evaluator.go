@@ -0,0 +1,284 @@
+package entity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// HandValue is the value of a five-card hand for an Evaluator. Higher
+// values win and equal values tie. Values of different evaluators cannot be
+// compared.
+type HandValue int
+
+// Evaluator ranks five-card hands for a game, so game modes can pick how
+// hands win: the highest hand, the lowest or a qualifying low.
+type Evaluator interface {
+	// Name is like "High" or "2-7 Lowball".
+	Name() string
+	// Value returns the value of a five-card hand. ok is false when the
+	// hand does not qualify, like a low with a 9 for 8-or-better, or when it
+	// is not five cards.
+	Value(hand []Trump) (value HandValue, ok bool)
+	// Describe names the hand, like "Flush (A J 9 5 3)" or "7-5-4-3-2".
+	Describe(hand []Trump) string
+}
+
+// Evaluators returns the built-in evaluators.
+func Evaluators() []Evaluator {
+	return []Evaluator{HighEvaluator{}, DeuceToSevenEvaluator{}, AceToFiveEvaluator{}, EightOrBetterEvaluator{}}
+}
+
+// FindEvaluator returns the built-in evaluator with the given name,
+// ignoring case.
+func FindEvaluator(name string) (Evaluator, error) {
+	var names []string
+	for _, e := range Evaluators() {
+		if strings.EqualFold(e.Name(), name) {
+			return e, nil
+		}
+		names = append(names, e.Name())
+	}
+	return nil, fmt.Errorf("unknown evaluator %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+// HighEvaluator ranks hands the standard way: the highest hand wins and the
+// Ace plays high or low in a straight.
+type HighEvaluator struct{}
+
+func (HighEvaluator) Name() string { return "High" }
+
+func (HighEvaluator) Value(hand []Trump) (HandValue, bool) {
+	if len(hand) != 5 {
+		return 0, false
+	}
+	return HandValue(RankHand(hand)), true
+}
+
+func (HighEvaluator) Describe(hand []Trump) string {
+	return EvaluateStrength(hand).String()
+}
+
+// DeuceToSevenEvaluator ranks hands for 2-7 lowball, as in 2-7 Triple Draw:
+// the lowest hand wins, Aces are always high, and straights and flushes
+// count against the hand. The best hand is 7-5-4-3-2 of mixed suits.
+type DeuceToSevenEvaluator struct{}
+
+func (DeuceToSevenEvaluator) Name() string { return "2-7 Lowball" }
+
+func (DeuceToSevenEvaluator) Value(hand []Trump) (HandValue, bool) {
+	if len(hand) != 5 {
+		return 0, false
+	}
+	handType, ranks := deuceToSeven(hand)
+	return lowValue(handType, ranks), true
+}
+
+func (DeuceToSevenEvaluator) Describe(hand []Trump) string {
+	handType, ranks := deuceToSeven(hand)
+	if handType == HighCard {
+		return lowName(ranks)
+	}
+	return HandStrength{HandType: handType, Ranks: ranks}.String()
+}
+
+// deuceToSeven returns the hand type and tie-break ranks of a hand when
+// A-2-3-4-5 is not a straight.
+func deuceToSeven(hand []Trump) (HandType, []int) {
+	ranks := groupedRanks(hand, false)
+	if len(ranks) < 5 {
+		return groupedType(hand, ranks), ranks
+	}
+
+	flush := isFlush(hand)
+	straight := ranks[0]-ranks[4] == 4
+	switch {
+	case straight && flush:
+		return StraightFlush, ranks[:1]
+	case straight:
+		return Straight, ranks[:1]
+	case flush:
+		return Flush, ranks
+	}
+	return HighCard, ranks
+}
+
+// AceToFiveEvaluator ranks hands for A-5 lowball, as in Razz: the lowest
+// hand wins, Aces are always low, and straights and flushes do not count.
+// The best hand is 5-4-3-2-A.
+type AceToFiveEvaluator struct{}
+
+func (AceToFiveEvaluator) Name() string { return "A-5 Lowball" }
+
+func (AceToFiveEvaluator) Value(hand []Trump) (HandValue, bool) {
+	if len(hand) != 5 {
+		return 0, false
+	}
+	ranks := groupedRanks(hand, true)
+	return lowValue(groupedType(hand, ranks), ranks), true
+}
+
+func (AceToFiveEvaluator) Describe(hand []Trump) string {
+	ranks := groupedRanks(hand, true)
+	handType := groupedType(hand, ranks)
+	if handType == HighCard {
+		return lowName(ranks)
+	}
+	// HandStrength names the Ace by its high rank
+	high := make([]int, len(ranks))
+	for i, rank := range ranks {
+		high[i] = aceHigh(rank)
+	}
+	return HandStrength{HandType: handType, Ranks: high}.String()
+}
+
+// EightOrBetterEvaluator ranks the low half of hi-lo split games like Omaha
+// Hi-Lo: an A-5 low that only qualifies with five different ranks of 8 or
+// lower.
+type EightOrBetterEvaluator struct{}
+
+func (EightOrBetterEvaluator) Name() string { return "8-or-Better" }
+
+func (EightOrBetterEvaluator) Value(hand []Trump) (HandValue, bool) {
+	if len(hand) != 5 {
+		return 0, false
+	}
+	ranks := groupedRanks(hand, true)
+	if len(ranks) < 5 || ranks[0] > 8 {
+		return 0, false
+	}
+	return lowValue(HighCard, ranks), true
+}
+
+func (EightOrBetterEvaluator) Describe(hand []Trump) string {
+	if _, ok := (EightOrBetterEvaluator{}).Value(hand); !ok {
+		return "No low"
+	}
+	return lowName(groupedRanks(hand, true))
+}
+
+// groupedRanks returns the different ranks of the hand, larger groups
+// first and then higher ranks first. With aceLow, Aces rank as 1.
+func groupedRanks(hand []Trump, aceLow bool) []int {
+	counts := make(map[int]int)
+	for _, card := range hand {
+		order := card.GetSortOrder()
+		if aceLow && card.Rank == Ace {
+			order = 1
+		}
+		counts[order]++
+	}
+	var ranks []int
+	for rank := range counts {
+		ranks = append(ranks, rank)
+	}
+	sort.Slice(ranks, func(i, j int) bool {
+		a, b := ranks[i], ranks[j]
+		if counts[a] != counts[b] {
+			return counts[a] > counts[b]
+		}
+		return a > b
+	})
+	return ranks
+}
+
+// groupedType returns the hand type made by the rank groups alone.
+func groupedType(hand []Trump, ranks []int) HandType {
+	switch len(ranks) {
+	case 5:
+		return HighCard
+	case 4:
+		return OnePair
+	case 3:
+		// Two pair leaves one rank with a single card, three of a kind two
+		if countRank(hand, ranks[0]) == 3 {
+			return ThreeOfAKind
+		}
+		return TwoPair
+	}
+	if countRank(hand, ranks[0]) == 4 {
+		return FourOfAKind
+	}
+	return FullHouse
+}
+
+func countRank(hand []Trump, rank int) int {
+	n := 0
+	for _, card := range hand {
+		order := card.GetSortOrder()
+		if order == rank || (rank == 1 && card.Rank == Ace) {
+			n++
+		}
+	}
+	return n
+}
+
+// lowValue turns a hand type and its ranks into a value where lower hands
+// are worth more.
+func lowValue(handType HandType, ranks []int) HandValue {
+	high := GetScore(handType)
+	for i := 0; i < 5; i++ {
+		high <<= 4
+		if i < len(ranks) {
+			high |= ranks[i]
+		}
+	}
+	return HandValue(1<<28 - high)
+}
+
+// lowName names a low by its ranks from the highest, like "8-6-4-2-A".
+func lowName(ranks []int) string {
+	names := make([]string, len(ranks))
+	for i, rank := range ranks {
+		names[i] = rankName(aceHigh(rank))
+	}
+	return strings.Join(names, "-")
+}
+
+func aceHigh(rank int) int {
+	if rank == 1 {
+		return 14
+	}
+	return rank
+}
+
+// BestFiveWith returns the five cards with the best value for the
+// evaluator, like the best low of the seven cards of Razz. ok is false when
+// no five cards qualify.
+func BestFiveWith(e Evaluator, cards []Trump) (best []Trump, value HandValue, ok bool) {
+	hand := make([]Trump, 5)
+	combinations(len(cards), 5, func(indexes []int) {
+		for i, index := range indexes {
+			hand[i] = cards[index]
+		}
+		best, value, ok = considerWith(e, best, value, ok, hand)
+	})
+	return best, value, ok
+}
+
+// BestOmahaWith returns the best Omaha hand for the evaluator, using exactly
+// two of the hole cards and three of the board cards. Omaha Hi-Lo uses it
+// with HighEvaluator and EightOrBetterEvaluator.
+func BestOmahaWith(e Evaluator, hole, board []Trump) (best []Trump, value HandValue, ok bool) {
+	hand := make([]Trump, 5)
+	combinations(len(hole), 2, func(holeIndexes []int) {
+		combinations(len(board), 3, func(boardIndexes []int) {
+			hand[0], hand[1] = hole[holeIndexes[0]], hole[holeIndexes[1]]
+			for i, index := range boardIndexes {
+				hand[2+i] = board[index]
+			}
+			best, value, ok = considerWith(e, best, value, ok, hand)
+		})
+	})
+	return best, value, ok
+}
+
+// considerWith returns a copy of hand and its value if it qualifies and
+// beats the best so far.
+func considerWith(e Evaluator, best []Trump, value HandValue, ok bool, hand []Trump) ([]Trump, HandValue, bool) {
+	v, qualifies := e.Value(hand)
+	if !qualifies || (ok && v <= value) {
+		return best, value, ok
+	}
+	return append(best[:0], hand...), v, true
+}
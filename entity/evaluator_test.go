@@ -0,0 +1,179 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+)
+
+// value returns the value of the hand for e, or -1 if it does not qualify.
+func value(t *testing.T, e Evaluator, hand string) HandValue {
+	t.Helper()
+	v, ok := e.Value(cards(t, hand))
+	if !ok {
+		return -1
+	}
+	return v
+}
+
+func TestEvaluatorOrder(t *testing.T) {
+	// Every list goes from the best hand to the worst for the evaluator
+	tests := []struct {
+		evaluator Evaluator
+		hands     []string
+	}{
+		{HighEvaluator{}, []string{
+			"Ah Kh Qh Jh Th",
+			"5s 4s 3s 2s As",
+			"Kc Kd Kh Ks 2c",
+			"Ac Kc 9c 5c 3c",
+			"5d 4c 3h 2s Ac",
+			"Ac Kd Qh Js 9c",
+		}},
+		{DeuceToSevenEvaluator{}, []string{
+			"7c 5d 4h 3s 2c",
+			"7c 6d 4h 3s 2c",
+			"8c 5d 4h 3s 2c",
+			"Kc Qd Jh Ts 8c",
+			// A-2-3-4-5 is Ace high, not a straight
+			"Ac 5d 4h 3s 2c",
+			"2c 2d 4h 3s 5c",
+			"Ac Ad Kh Qs Jc",
+			"7c 7d 7h 3s 2c",
+			"6c 5d 4h 3s 2c",
+			"7c 5c 4c 3c 2c",
+		}},
+		{AceToFiveEvaluator{}, []string{
+			// Straights and flushes do not count
+			"5c 4c 3c 2c Ac",
+			"6c 4d 3h 2s Ac",
+			"6c 5d 4h 3s 2c",
+			"8c 7d 6h 5s 4c",
+			"Kc Qd Jh Ts 9c",
+			"Ac Ad 3h 4s 5c",
+			"2c 2d 3h 3s Ac",
+			"Ac Ad Ah Ks Kc",
+		}},
+		{EightOrBetterEvaluator{}, []string{
+			"5c 4c 3c 2c Ac",
+			"7c 6d 4h 3s 2c",
+			"8c 7d 6h 5s 4c",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.evaluator.Name(), func(t *testing.T) {
+			for i := 1; i < len(tt.hands); i++ {
+				better, worse := value(t, tt.evaluator, tt.hands[i-1]), value(t, tt.evaluator, tt.hands[i])
+				if better <= worse {
+					t.Errorf("%s (%d) should beat %s (%d)", tt.hands[i-1], better, tt.hands[i], worse)
+				}
+			}
+		})
+	}
+}
+
+func TestEvaluatorTies(t *testing.T) {
+	for _, e := range Evaluators() {
+		if a, b := value(t, e, "7c 5d 4h 3s 2c"), value(t, e, "7d 5h 4s 3c 2d"); a != b {
+			t.Errorf("%s: the same ranks in other suits are %d and %d", e.Name(), a, b)
+		}
+	}
+	if a, b := value(t, AceToFiveEvaluator{}, "Ac Ad 3h 4s 5c"), value(t, AceToFiveEvaluator{}, "Ah As 3d 4d 5d"); a != b {
+		t.Errorf("A-5: a flush should not matter, got %d and %d", a, b)
+	}
+}
+
+func TestEightOrBetterQualifier(t *testing.T) {
+	tests := []struct {
+		hand string
+		want bool
+	}{
+		{"8c 7d 6h 5s 4c", true},
+		{"Ac 2d 3h 4s 8c", true},
+		{"9c 4d 3h 2s Ac", false},
+		{"7c 7d 3h 2s Ac", false},
+		{"Kc Qd 3h 2s Ac", false},
+		{"7c 5d 4h 3s", false},
+	}
+	for _, tt := range tests {
+		if _, got := (EightOrBetterEvaluator{}).Value(cards(t, tt.hand)); got != tt.want {
+			t.Errorf("Value(%s) qualifies = %v, want %v", tt.hand, got, tt.want)
+		}
+	}
+}
+
+func TestEvaluatorDescribe(t *testing.T) {
+	tests := []struct {
+		evaluator Evaluator
+		hand      string
+		want      string
+	}{
+		{HighEvaluator{}, "Ac Jc 9c 5c 3c", "Flush (A J 9 5 3)"},
+		{DeuceToSevenEvaluator{}, "2c 5d 3h 7s 4c", "7-5-4-3-2"},
+		{DeuceToSevenEvaluator{}, "Ac 5d 3h 2s 4c", "A-5-4-3-2"},
+		{DeuceToSevenEvaluator{}, "6c 5d 4h 3s 2c", "Straight (6)"},
+		{AceToFiveEvaluator{}, "Ac 5d 3h 2s 4c", "5-4-3-2-A"},
+		{AceToFiveEvaluator{}, "Ac Ad 3h 2s 4c", "One Pair (A 4 3 2)"},
+		{EightOrBetterEvaluator{}, "8c 6d 4h 2s Ac", "8-6-4-2-A"},
+		{EightOrBetterEvaluator{}, "9c 6d 4h 2s Ac", "No low"},
+	}
+	for _, tt := range tests {
+		if got := tt.evaluator.Describe(cards(t, tt.hand)); got != tt.want {
+			t.Errorf("%s Describe(%s) = %q, want %q", tt.evaluator.Name(), tt.hand, got, tt.want)
+		}
+	}
+}
+
+func TestBestFiveWith(t *testing.T) {
+	// Razz: the best A-5 low of seven cards
+	best, _, ok := BestFiveWith(AceToFiveEvaluator{}, cards(t, "Kc 7d 7h 4s Ac 2d 6c"))
+	if !ok || (AceToFiveEvaluator{}).Describe(best) != "7-6-4-2-A" {
+		t.Errorf("BestFiveWith(A-5) = %v, %v", best, ok)
+	}
+
+	// Nothing qualifies for 8-or-better
+	if _, _, ok := BestFiveWith(EightOrBetterEvaluator{}, cards(t, "Kc 7d 7h 4s Ac 9d 9c")); ok {
+		t.Error("BestFiveWith(8-or-Better) qualified without five low ranks")
+	}
+
+	// The high evaluator agrees with BestFive
+	seven := cards(t, "Kc Kd 7h 4s Ac 2d Kh")
+	best, _, _ = BestFiveWith(HighEvaluator{}, seven)
+	want, err := BestFive(seven)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if EvaluateStrength(best).Compare(want.Strength) != 0 {
+		t.Errorf("BestFiveWith(High) = %v, BestFive = %v", best, want.Cards)
+	}
+}
+
+func TestBestOmahaWith(t *testing.T) {
+	hole := cards(t, "Ac 2d Kh Ks")
+	board := cards(t, "3c 5d 8h Kd Qc")
+
+	// The low uses A-2 from the hole and 3-5-8 from the board
+	low, _, ok := BestOmahaWith(EightOrBetterEvaluator{}, hole, board)
+	if !ok || (EightOrBetterEvaluator{}).Describe(low) != "8-5-3-2-A" {
+		t.Errorf("BestOmahaWith(8-or-Better) = %v, %v", low, ok)
+	}
+	// The high uses K-K from the hole for three Kings
+	high, _, _ := BestOmahaWith(HighEvaluator{}, hole, board)
+	if got := EvaluateHand(high); got != ThreeOfAKind {
+		t.Errorf("BestOmahaWith(High) = %v, a %s", high, got)
+	}
+
+	// Only two low board cards: no low
+	if _, _, ok := BestOmahaWith(EightOrBetterEvaluator{}, hole, cards(t, "3c 5d Th Kd Qc")); ok {
+		t.Error("BestOmahaWith(8-or-Better) qualified with two low board cards")
+	}
+}
+
+func TestFindEvaluator(t *testing.T) {
+	e, err := FindEvaluator("2-7 lowball")
+	if err != nil || e.Name() != "2-7 Lowball" {
+		t.Errorf("FindEvaluator(2-7 lowball) = %v, %v", e, err)
+	}
+	if _, err := FindEvaluator("badugi"); err == nil || !strings.Contains(err.Error(), "available: High") {
+		t.Errorf("FindEvaluator(badugi) error = %v", err)
+	}
+}
@@ -0,0 +1,298 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// HandRanking decides which hand type cards make and how hand types rank
+// against each other, so rulesets can play variants like short deck.
+type HandRanking struct {
+	Name string
+	// Order lists every hand type once, from the weakest to the strongest.
+	Order []HandType
+	// LowestRank is the sort order of the lowest rank in the deck, 2 for
+	// the standard deck and 6 for short deck. The Ace also plays just below
+	// it in a straight, like A-2-3-4-5 or A-6-7-8-9.
+	LowestRank int
+	// FourCardFlushes and FourCardStraights let four cards of a hand make a
+	// flush or a straight. A straight flush then needs both, not
+	// necessarily from the same cards.
+	FourCardFlushes   bool
+	FourCardStraights bool
+	// GapStraights let a straight skip one rank between its cards, like
+	// 3-5-6-8-9.
+	GapStraights bool
+}
+
+// StandardRanking returns the standard ranking of hand types with a
+// 52-card deck.
+func StandardRanking() HandRanking {
+	return HandRanking{
+		Name:       "standard",
+		Order:      HandTypes(),
+		LowestRank: 2,
+	}
+}
+
+// ShortDeckRanking returns the ranking of short deck hold'em: the 36-card
+// deck has no 2 to 5 and a Flush beats a Full House, because it is harder
+// to make.
+func ShortDeckRanking() HandRanking {
+	return HandRanking{
+		Name: "short-deck",
+		Order: []HandType{
+			HighCard,
+			OnePair,
+			TwoPair,
+			ThreeOfAKind,
+			Straight,
+			FullHouse,
+			Flush,
+			FourOfAKind,
+			StraightFlush,
+			RoyalFlush,
+		},
+		LowestRank: 6,
+	}
+}
+
+// HandRankings returns the built-in rankings.
+func HandRankings() []HandRanking {
+	return []HandRanking{StandardRanking(), ShortDeckRanking()}
+}
+
+// FindHandRanking returns the built-in ranking with the given name,
+// ignoring case.
+func FindHandRanking(name string) (HandRanking, error) {
+	var names []string
+	for _, r := range HandRankings() {
+		if strings.EqualFold(r.Name, name) {
+			return r, nil
+		}
+		names = append(names, r.Name)
+	}
+	return HandRanking{}, fmt.Errorf("unknown hand ranking %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+var standardRanking = StandardRanking()
+
+// Validate returns all problems of the ranking joined into one error.
+func (r HandRanking) Validate() error {
+	var errs []error
+	seen := make(map[HandType]bool)
+	for _, handType := range r.Order {
+		if GetScore(handType) == 0 {
+			errs = append(errs, fmt.Errorf("order has unknown hand type %q", handType))
+		} else if seen[handType] {
+			errs = append(errs, fmt.Errorf("order has %s more than once", handType))
+		}
+		seen[handType] = true
+	}
+	for _, handType := range HandTypes() {
+		if !seen[handType] {
+			errs = append(errs, fmt.Errorf("order is missing %s", handType))
+		}
+	}
+	// At least five ranks must be left for a straight
+	if r.LowestRank < 2 || r.LowestRank > 10 {
+		errs = append(errs, fmt.Errorf("lowest_rank must be between 2 and 10, got %d", r.LowestRank))
+	}
+	return errors.Join(errs...)
+}
+
+// Score returns the position of the hand type in the ranking, from 1 for the
+// weakest, or 0 if the ranking does not have it.
+func (r HandRanking) Score(handType HandType) int {
+	for i, t := range r.Order {
+		if t == handType {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// InDeck reports whether the card is in the deck of the ranking.
+func (r HandRanking) InDeck(card Trump) bool {
+	return card.GetSortOrder() >= r.LowestRank
+}
+
+// NewDeck returns the deck of the ranking, like the 36 cards from 6 to Ace
+// of short deck.
+func (r HandRanking) NewDeck() Deck {
+	return r.Filter(NewDeck())
+}
+
+// Filter returns the cards of the deck that are in the deck of the ranking.
+func (r HandRanking) Filter(deck Deck) Deck {
+	var kept Deck
+	for _, card := range deck {
+		if r.InDeck(card) {
+			kept = append(kept, card)
+		}
+	}
+	return kept
+}
+
+// Evaluate returns the strongest hand type the hand makes. The hand has at
+// most 5 cards.
+func (r HandRanking) Evaluate(hand []Trump) HandType {
+	s := r.shape(hand)
+	for i := len(r.Order) - 1; i >= 0; i-- {
+		if s.makes(r.Order[i]) {
+			return r.Order[i]
+		}
+	}
+	return HighCard
+}
+
+// Strength returns the hand type and tie-break ranks of the hand.
+func (r HandRanking) Strength(hand []Trump) HandStrength {
+	s := r.shape(hand)
+	strength := HandStrength{HandType: r.Evaluate(hand)}
+
+	switch strength.HandType {
+	case Straight, StraightFlush, RoyalFlush:
+		strength.Ranks = []int{s.straightHigh}
+		return strength
+	}
+
+	// Order the ranks by group size and then by rank
+	for rank := 2; rank <= 14; rank++ {
+		if s.counts[rank] > 0 {
+			strength.Ranks = append(strength.Ranks, rank)
+		}
+	}
+	sort.SliceStable(strength.Ranks, func(i, j int) bool {
+		a, b := strength.Ranks[i], strength.Ranks[j]
+		if s.counts[a] != s.counts[b] {
+			return s.counts[a] > s.counts[b]
+		}
+		return a > b
+	})
+	return strength
+}
+
+// Compare returns a negative number if a is weaker than b in the ranking, a
+// positive number if it is stronger and 0 if they tie.
+func (r HandRanking) Compare(a, b HandStrength) int {
+	if d := r.Score(a.HandType) - r.Score(b.HandType); d != 0 {
+		return d
+	}
+	return compareRanks(a.Ranks, b.Ranks)
+}
+
+// handShape is what a hand is made of, for deciding which hand types it
+// makes.
+type handShape struct {
+	// counts is the number of cards of every sort order
+	counts [15]int
+	// groups are the two largest numbers of cards of one rank
+	groups       [2]int
+	flush        bool
+	straight     bool
+	straightHigh int
+	royal        bool
+}
+
+func (r HandRanking) shape(hand []Trump) handShape {
+	var s handShape
+	suits := make(map[Suit]int)
+	for _, card := range hand {
+		s.counts[card.GetSortOrder()]++
+		suits[card.Suit]++
+	}
+	for _, count := range s.counts {
+		if count > s.groups[0] {
+			s.groups[0], s.groups[1] = count, s.groups[0]
+		} else if count > s.groups[1] {
+			s.groups[1] = count
+		}
+	}
+
+	size := 5
+	if r.FourCardFlushes {
+		size = 4
+	}
+	for _, count := range suits {
+		s.flush = s.flush || count >= size
+	}
+
+	s.straightHigh, s.straight = r.straight(s.counts)
+	s.royal = s.straight && s.flush
+	for rank := 10; rank <= 14; rank++ {
+		s.royal = s.royal && s.counts[rank] > 0
+	}
+	return s
+}
+
+// straight returns the highest card of the highest straight in the counts
+// of the ranks. An Ace that plays low counts as LowestRank-1.
+func (r HandRanking) straight(counts [15]int) (high int, ok bool) {
+	var present [15]bool
+	for rank := 2; rank <= 14; rank++ {
+		present[rank] = counts[rank] > 0
+	}
+	acePresent := present[14]
+	if r.LowestRank >= 2 {
+		present[r.LowestRank-1] = present[r.LowestRank-1] || acePresent
+	}
+
+	size, step := 5, 1
+	if r.FourCardStraights {
+		size = 4
+	}
+	if r.GapStraights {
+		step = 2
+	}
+
+	start, last, length := 0, 0, 0
+	for rank := 1; rank <= 14; rank++ {
+		if !present[rank] {
+			continue
+		}
+		if length == 0 || rank-last > step {
+			start, length = rank, 0
+		}
+		last = rank
+		length++
+
+		cards := length
+		// The same Ace cannot play both low and high
+		if acePresent && start == r.LowestRank-1 && rank == 14 {
+			cards--
+		}
+		if cards >= size {
+			high, ok = rank, true
+		}
+	}
+	return high, ok
+}
+
+func (s handShape) makes(handType HandType) bool {
+	switch handType {
+	case HighCard:
+		return true
+	case OnePair:
+		return s.groups[0] >= 2
+	case TwoPair:
+		return s.groups[1] >= 2
+	case ThreeOfAKind:
+		return s.groups[0] >= 3
+	case Straight:
+		return s.straight
+	case Flush:
+		return s.flush
+	case FullHouse:
+		return s.groups[0] >= 3 && s.groups[1] >= 2
+	case FourOfAKind:
+		return s.groups[0] >= 4
+	case StraightFlush:
+		return s.straight && s.flush
+	case RoyalFlush:
+		return s.royal
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHandRankingEvaluate(t *testing.T) {
+	fourCards := StandardRanking()
+	fourCards.FourCardFlushes = true
+	fourCards.FourCardStraights = true
+	gaps := StandardRanking()
+	gaps.GapStraights = true
+
+	tests := []struct {
+		name    string
+		ranking HandRanking
+		hand    string
+		want    HandType
+	}{
+		{"standard wheel", StandardRanking(), "Ac 2d 3h 4s 5c", Straight},
+		{"standard flush", StandardRanking(), "2s 9s Js 4s 7s", Flush},
+		{"standard no wrap", StandardRanking(), "Qc Kd Ah 2s 3c", HighCard},
+		{"short deck wheel", ShortDeckRanking(), "Ac 6d 7h 8s 9c", Straight},
+		{"short deck royal", ShortDeckRanking(), "Ah Kh Qh Jh Th", RoyalFlush},
+		{"short deck low straight flush", ShortDeckRanking(), "Ad 6d 7d 8d 9d", StraightFlush},
+		{"short deck flush", ShortDeckRanking(), "6s 9s Js Qs As", Flush},
+		{"short deck full house", ShortDeckRanking(), "6c 6d 6h As Ac", FullHouse},
+		{"four card flush", fourCards, "2s 9s Js 4s 7d", Flush},
+		{"four card straight", fourCards, "5c 6d 7h 8s Kc", Straight},
+		{"four card wheel", fourCards, "Ac 2d 3h 4s", Straight},
+		{"four card straight flush", fourCards, "5s 6s 7s 8s", StraightFlush},
+		{"four cards need the option", StandardRanking(), "5c 6d 7h 8s Kc", HighCard},
+		{"gap straight", gaps, "3c 5d 6h 8s 9c", Straight},
+		{"gaps of one rank only", gaps, "3c 6d 7h 8s 9c", HighCard},
+		{"gap royal", gaps, "Ah Kh Qh Jh Th", RoyalFlush},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ranking.Evaluate(cards(t, tt.hand)); got != tt.want {
+				t.Errorf("Evaluate(%s) = %s, want %s", tt.hand, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandRankingCompare(t *testing.T) {
+	flush := ShortDeckRanking().Strength(cards(t, "6s 9s Js Qs As"))
+	fullHouse := ShortDeckRanking().Strength(cards(t, "Kc Kd Kh As Ac"))
+	if ShortDeckRanking().Compare(flush, fullHouse) <= 0 {
+		t.Errorf("short deck: %s should beat %s", flush, fullHouse)
+	}
+	if StandardRanking().Compare(flush, fullHouse) >= 0 {
+		t.Errorf("standard: %s should lose to %s", flush, fullHouse)
+	}
+
+	// A-6-7-8-9 is the lowest short deck straight
+	wheel := ShortDeckRanking().Strength(cards(t, "Ac 6d 7h 8s 9c"))
+	next := ShortDeckRanking().Strength(cards(t, "6c 7d 8h 9s Tc"))
+	if wheel.String() != "Straight (9)" || ShortDeckRanking().Compare(wheel, next) >= 0 {
+		t.Errorf("short deck: %s should lose to %s", wheel, next)
+	}
+}
+
+func TestHandRankingDeck(t *testing.T) {
+	if n := len(StandardRanking().NewDeck()); n != 52 {
+		t.Errorf("standard deck has %d cards, want 52", n)
+	}
+	deck := ShortDeckRanking().NewDeck()
+	if len(deck) != 36 {
+		t.Errorf("short deck has %d cards, want 36", len(deck))
+	}
+	for _, card := range deck {
+		if card.GetSortOrder() < 6 {
+			t.Errorf("short deck has %v", card)
+		}
+	}
+}
+
+func TestHandRankingValidate(t *testing.T) {
+	for _, r := range HandRankings() {
+		if err := r.Validate(); err != nil {
+			t.Errorf("%s: Validate() = %v", r.Name, err)
+		}
+	}
+
+	r := StandardRanking()
+	r.Order = []HandType{HighCard, OnePair, OnePair, "Five of a Kind"}
+	r.LowestRank = 11
+	err := r.Validate()
+	for _, want := range []string{"One Pair more than once", "unknown hand type", "missing Flush", "lowest_rank"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() = %v, want it to mention %q", err, want)
+		}
+	}
+
+	if _, err := FindHandRanking("Short-Deck"); err != nil {
+		t.Errorf("FindHandRanking(Short-Deck) = %v", err)
+	}
+	if _, err := FindHandRanking("pineapple"); err == nil || !strings.Contains(err.Error(), "available: standard, short-deck") {
+		t.Errorf("FindHandRanking(pineapple) error = %v", err)
+	}
+}
+
+func TestRunInfoHandRanking(t *testing.T) {
+	rules := DefaultRules()
+	ranking := ShortDeckRanking()
+	rules.PokerHands.Ranking = &ranking
+	r := NewRunInfoWithRules(rules)
+
+	if len(r.Deck) != 36 {
+		t.Errorf("Deck has %d cards, want 36", len(r.Deck))
+	}
+	red, _ := FindStartingDeck("Red")
+	r.UseDeck(red)
+	if len(r.Deck) != 36 {
+		t.Errorf("Deck has %d cards after UseDeck, want 36", len(r.Deck))
+	}
+
+	stats, err := r.ScoreHand(cards(t, "Ac 6d 7h 8s 9c"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.HandType != Straight {
+		t.Errorf("ScoreHand(A 6 7 8 9) = %s, want Straight", stats.HandType)
+	}
+}
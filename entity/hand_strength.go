@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HandStrength is the strength of a poker hand: its hand type and the ranks
+// that break ties between hands of the same type, most significant first.
+type HandStrength struct {
+	HandType HandType
+	// Ranks are sort orders from 2 to 14 (Ace). Groups come first, larger
+	// groups before smaller ones, followed by the kickers, e.g. a Full House
+	// of Kings over 9s is [13, 9] and One Pair of 7s is [7, 14, 10, 4]. A
+	// straight is ranked by its highest card, which is the 5 for A-2-3-4-5.
+	Ranks []int
+}
+
+// EvaluateStrength returns the hand type and tie-break ranks of the hand
+// with the standard ranking.
+func EvaluateStrength(hand []Trump) HandStrength {
+	return standardRanking.Strength(hand)
+}
+
+// Compare returns a negative number if s is weaker than other, a positive
+// number if it is stronger and 0 if they tie.
+func (s HandStrength) Compare(other HandStrength) int {
+	return standardRanking.Compare(s, other)
+}
+
+// compareRanks compares tie-break ranks, most significant first.
+func compareRanks(a, b []int) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if d := a[i] - b[i]; d != 0 {
+			return d
+		}
+	}
+	return len(a) - len(b)
+}
+
+func (s HandStrength) String() string {
+	ranks := make([]string, len(s.Ranks))
+	for i, rank := range s.Ranks {
+		ranks[i] = rankName(rank)
+	}
+	return fmt.Sprintf("%s (%s)", s.HandType, strings.Join(ranks, " "))
+}
+
+// CompareHands compares two hands by their strength. It returns a negative
+// number if a loses, a positive number if a wins and 0 on a tie.
+func CompareHands(a, b []Trump) int {
+	return EvaluateStrength(a).Compare(EvaluateStrength(b))
+}
+
+func rankName(order int) string {
+	for _, card := range NewDeck()[:13] {
+		if card.GetSortOrder() == order {
+			return string(card.Rank)
+		}
+	}
+	return fmt.Sprint(order)
+}
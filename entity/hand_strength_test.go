@@ -0,0 +1,83 @@
+package entity
+
+import "testing"
+
+// cards builds a hand from short names such as "Ah Td 2c".
+func cards(t *testing.T, s string) []Trump {
+	t.Helper()
+	hand, err := ParseCards(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hand
+}
+
+func TestEvaluateStrength(t *testing.T) {
+	tests := []struct {
+		hand string
+		want string
+	}{
+		{"Ah Kh Qh Jh Th", "Royal Flush (A)"},
+		{"5d 4d 3d 2d Ad", "Straight Flush (5)"},
+		{"9c 9d 9h 9s 2c", "Four of a Kind (9 2)"},
+		{"3c 3d Kh Ks Kc", "Full House (K 3)"},
+		{"2s 9s Js 4s 7s", "Flush (J 9 7 4 2)"},
+		{"Ac 2d 3h 4s 5c", "Straight (5)"},
+		{"Tc Jd Qh Ks Ac", "Straight (A)"},
+		{"7c 7d 7h As 2c", "Three of a Kind (7 A 2)"},
+		{"4c 4d Jh Js 9c", "Two Pair (J 4 9)"},
+		{"7c 7d Ah Ts 4c", "One Pair (7 A T 4)"},
+		{"Kc 2d 9h", "High Card (K 9 2)"},
+	}
+
+	for _, tt := range tests {
+		if got := EvaluateStrength(cards(t, tt.hand)).String(); got != tt.want {
+			t.Errorf("EvaluateStrength(%s) = %s, want %s", tt.hand, got, tt.want)
+		}
+	}
+}
+
+func TestCompareHands(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want int
+	}{
+		{"flush kicker", "Ah 9h 7h 5h 3h", "Ad 9d 7d 5d 2d", 1},
+		{"pair beats high card", "2c 2d 5h 7s 9c", "Ac Kd Qh Js 9d", 1},
+		{"higher pair", "Kc Kd 5h 7s 9c", "Qc Qd Ah Js 9d", 1},
+		{"pair kicker", "7c 7d Ah 5s 3c", "7h 7s Kh Qs Jc", 1},
+		{"last kicker", "7c 7d Ah Ks 3c", "7h 7s Ad Kd 2c", 1},
+		{"two pair high pair", "Jc Jd 3h 3s 2c", "Tc Td 9h 9s Ac", 1},
+		{"two pair kicker", "Jc Jd 3h 3s 5c", "Jh Js 3c 3d 4c", 1},
+		{"full house by trips", "3c 3d 3h 2s 2c", "2d 2h 2s Ac Ad", 1},
+		{"wheel is the lowest straight", "Ac 2d 3h 4s 5c", "2c 3d 4h 5s 6c", -1},
+		{"broadway beats wheel", "Tc Jd Qh Ks Ac", "Ac 2d 3h 4s 5c", 1},
+		{"steel wheel", "Ad 2d 3d 4d 5d", "6c 2c 3c 4c 5c", -1},
+		{"royal beats straight flush", "Ah Kh Qh Jh Th", "Ks Qs Js Ts 9s", 1},
+		{"suits do not matter", "Ah Kd 9c 7s 3h", "As Kc 9d 7h 3c", 0},
+		{"split straight", "9c Td Jh Qs Kc", "9d Tc Js Qh Kd", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := cards(t, tt.a), cards(t, tt.b)
+			if got := sign(CompareHands(a, b)); got != tt.want {
+				t.Errorf("CompareHands(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+			if got := sign(CompareHands(b, a)); got != -tt.want {
+				t.Errorf("CompareHands(%s, %s) = %d, want %d", tt.b, tt.a, got, -tt.want)
+			}
+		})
+	}
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,36 @@
+package entity
+
+import "fmt"
+
+// Hooks is custom logic of a joker or a boss blind, such as a script,
+// called at game events. The scoring hooks change the stats of the played
+// hand. They are also called to preview a hand, so they must not change
+// any state of their own; OnDiscard and OnRoundEnd may.
+type Hooks interface {
+	// OnCardScored is called for every scoring card that is not debuffed.
+	OnCardScored(card Trump, stats *PokerHandStats) error
+	// OnHandScored is called once per hand after the cards are scored.
+	OnHandScored(stats *PokerHandStats) error
+	OnDiscard(cards []Trump) error
+	OnRoundEnd(stats RoundStats) error
+}
+
+// scoreHooks calls the scoring hooks and updates the score.
+func scoreHooks(hooks Hooks, stats *PokerHandStats, debuffed func(Trump) bool) error {
+	if hooks == nil {
+		return nil
+	}
+	for _, card := range stats.ScoringCards {
+		if debuffed(card) {
+			continue
+		}
+		if err := hooks.OnCardScored(card, stats); err != nil {
+			return fmt.Errorf("on_card_scored: %w", err)
+		}
+	}
+	if err := hooks.OnHandScored(stats); err != nil {
+		return fmt.Errorf("on_hand_scored: %w", err)
+	}
+	stats.Score = stats.Chip * stats.Mult
+	return nil
+}
@@ -0,0 +1,102 @@
+package entity
+
+import "math"
+
+// Joker changes the score of every played hand with its effects.
+type Joker struct {
+	ID          string
+	Name        string
+	Description string
+	Effects     []Effect
+	// Hooks are called after the effects are applied, if set.
+	Hooks Hooks
+	// UnlockedBy is the ID of the achievement that unlocks the joker, if it
+	// is locked.
+	UnlockedBy string
+}
+
+// Effect adds chips or mult, or multiplies the mult, of a played hand.
+// Without ForEach it applies once if If matches. With ForEach it applies
+// once for every scoring card that matches.
+type Effect struct {
+	If      *HandCondition
+	ForEach *CardCondition
+	Chips   int
+	Mult    int
+	// XMult multiplies the mult when it is not zero. The result is rounded
+	// to the nearest whole mult.
+	XMult float64
+}
+
+// HandCondition matches played hands that contain a hand type, so that a
+// Full House also contains a Three of a Kind and a One Pair.
+type HandCondition struct {
+	Contains HandType
+}
+
+// CardCondition matches cards by suit and rank. Empty fields match any card.
+type CardCondition struct {
+	Suit Suit
+	Rank Rank
+}
+
+func (c HandCondition) Match(stats PokerHandStats) bool {
+	return HandContains(stats.HandType, c.Contains)
+}
+
+func (c CardCondition) Match(card Trump) bool {
+	return (c.Suit == "" || c.Suit == card.Suit) && (c.Rank == "" || c.Rank == card.Rank)
+}
+
+// Apply applies the effects of the joker to the stats and updates the score.
+// Debuffed cards do not trigger ForEach effects.
+func (j Joker) Apply(stats *PokerHandStats, debuffed func(Trump) bool) {
+	for _, e := range j.Effects {
+		if e.If != nil && !e.If.Match(*stats) {
+			continue
+		}
+
+		times := 1
+		if e.ForEach != nil {
+			times = 0
+			for _, card := range stats.ScoringCards {
+				if e.ForEach.Match(card) && (debuffed == nil || !debuffed(card)) {
+					times++
+				}
+			}
+		}
+
+		for i := 0; i < times; i++ {
+			stats.Chip += e.Chips
+			stats.Mult += e.Mult
+			if e.XMult != 0 {
+				stats.Mult = int(math.Round(float64(stats.Mult) * e.XMult))
+			}
+		}
+	}
+	stats.Score = stats.Chip * stats.Mult
+}
+
+// handContains lists the hand types each hand type contains besides itself.
+var handContains = map[HandType][]HandType{
+	TwoPair:       {OnePair},
+	ThreeOfAKind:  {OnePair},
+	FullHouse:     {OnePair, TwoPair, ThreeOfAKind},
+	FourOfAKind:   {OnePair, TwoPair, ThreeOfAKind},
+	StraightFlush: {Straight, Flush},
+	RoyalFlush:    {Straight, Flush, StraightFlush},
+}
+
+// HandContains reports whether a hand of type hand contains the hand type
+// part, for example a Full House contains a One Pair.
+func HandContains(hand, part HandType) bool {
+	if hand == part || part == HighCard {
+		return true
+	}
+	for _, handType := range handContains[hand] {
+		if handType == part {
+			return true
+		}
+	}
+	return false
+}
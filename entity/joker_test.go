@@ -0,0 +1,62 @@
+package entity
+
+import "testing"
+
+func TestJokerApply(t *testing.T) {
+	pair := PokerHandStats{
+		HandType:     OnePair,
+		ScoringCards: []Trump{{Hearts, Ace}, {Spades, Ace}},
+		Chip:         32,
+		Mult:         2,
+		Score:        64,
+	}
+
+	tests := []struct {
+		name     string
+		effect   Effect
+		debuffed func(Trump) bool
+		wantChip int
+		wantMult int
+	}{
+		{"plain mult", Effect{Mult: 4}, nil, 32, 6},
+		{"matching hand", Effect{If: &HandCondition{Contains: OnePair}, Chips: 10}, nil, 42, 2},
+		{"other hand", Effect{If: &HandCondition{Contains: Flush}, Mult: 20}, nil, 32, 2},
+		{"for each heart", Effect{ForEach: &CardCondition{Suit: Hearts}, XMult: 1.5}, nil, 32, 3},
+		{"for each ace", Effect{ForEach: &CardCondition{Rank: Ace}, Mult: 1}, nil, 32, 4},
+		{"debuffed", Effect{ForEach: &CardCondition{Rank: Ace}, Mult: 1}, func(c Trump) bool { return c.Suit == Spades }, 32, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := pair
+			Joker{Effects: []Effect{tt.effect}}.Apply(&stats, tt.debuffed)
+
+			if stats.Chip != tt.wantChip || stats.Mult != tt.wantMult {
+				t.Errorf("Chip, Mult = %d, %d, want %d, %d", stats.Chip, stats.Mult, tt.wantChip, tt.wantMult)
+			}
+			if stats.Score != stats.Chip*stats.Mult {
+				t.Errorf("Score = %d, want %d", stats.Score, stats.Chip*stats.Mult)
+			}
+		})
+	}
+}
+
+func TestHandContains(t *testing.T) {
+	tests := []struct {
+		hand, part HandType
+		want       bool
+	}{
+		{FullHouse, OnePair, true},
+		{FullHouse, ThreeOfAKind, true},
+		{RoyalFlush, Flush, true},
+		{Flush, HighCard, true},
+		{OnePair, TwoPair, false},
+		{Straight, Flush, false},
+	}
+
+	for _, tt := range tests {
+		if got := HandContains(tt.hand, tt.part); got != tt.want {
+			t.Errorf("HandContains(%s, %s) = %v, want %v", tt.hand, tt.part, got, tt.want)
+		}
+	}
+}
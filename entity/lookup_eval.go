@@ -0,0 +1,257 @@
+package entity
+
+import (
+	"sort"
+	"sync"
+)
+
+// CardCode is a card encoded for the lookup-table evaluator, in the layout
+// popularized by Cactus Kev:
+//
+//	xxxbbbbb bbbbbbbb cdhsrrrr xxpppppp
+//
+// b is one bit per rank, cdhs is the suit bit, r is the rank index (2 is 0,
+// Ace is 12) and p is the prime of the rank.
+type CardCode uint32
+
+// HandRank is the strength of a five-card hand as a number from 1
+// (7-5-4-3-2 high card) to 7462 (Royal Flush). Higher is stronger, equal
+// ranks tie, and the order is the same as HandStrength.Compare.
+type HandRank uint16
+
+var rankPrimes = [13]uint32{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41}
+
+// EncodeCard returns the code of the card, which must be a card of the
+// standard deck.
+func EncodeCard(t Trump) CardCode {
+	var suit uint32
+	switch t.Suit {
+	case Clubs:
+		suit = 0x8000
+	case Diamonds:
+		suit = 0x4000
+	case Hearts:
+		suit = 0x2000
+	case Spades:
+		suit = 0x1000
+	}
+	r := uint32(t.GetSortOrder() - 2)
+	return CardCode(rankPrimes[r] | r<<8 | suit | 1<<(16+r))
+}
+
+// The tables are built on first use.
+var (
+	tablesOnce sync.Once
+	// flushes and unique5 are indexed by the rank bits of hands with five
+	// different ranks, with and without a flush.
+	flushes [1 << 13]HandRank
+	unique5 [1 << 13]HandRank
+	// products are the sorted prime products of hands with paired ranks,
+	// and productRanks their hand ranks.
+	products     []uint32
+	productRanks []HandRank
+	// rankTypes is the hand type of every hand rank.
+	rankTypes []HandType
+)
+
+// Evaluate5 returns the rank of five different cards without allocating.
+// The cards are not checked: repeated cards give 0 or an arbitrary rank.
+func Evaluate5(c1, c2, c3, c4, c5 CardCode) HandRank {
+	tablesOnce.Do(buildTables)
+
+	q := (c1 | c2 | c3 | c4 | c5) >> 16
+	if c1&c2&c3&c4&c5&0xF000 != 0 {
+		return flushes[q]
+	}
+	if r := unique5[q]; r != 0 {
+		return r
+	}
+
+	product := uint32(c1&0xFF) * uint32(c2&0xFF) * uint32(c3&0xFF) * uint32(c4&0xFF) * uint32(c5&0xFF)
+	lo, hi := 0, len(products)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if products[mid] < product {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	if lo < len(products) && products[lo] == product {
+		return productRanks[lo]
+	}
+	// Not a hand of one deck
+	return 0
+}
+
+// RankHand returns the rank of a hand of five different cards, or 0 for
+// any other hand.
+func RankHand(hand []Trump) HandRank {
+	if len(hand) != 5 {
+		return 0
+	}
+	var codes [5]CardCode
+	if !encodeHand(hand, codes[:]) {
+		return 0
+	}
+	return Evaluate5(codes[0], codes[1], codes[2], codes[3], codes[4])
+}
+
+// encodeHand encodes the hand into codes and reports whether it is made of
+// different cards of the standard deck.
+func encodeHand(hand []Trump, codes []CardCode) bool {
+	for i, card := range hand {
+		if card.GetSortOrder() == 0 || !isSuit(card.Suit) {
+			return false
+		}
+		codes[i] = EncodeCard(card)
+		for _, code := range codes[:i] {
+			if code == codes[i] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isSuit(suit Suit) bool {
+	switch suit {
+	case Clubs, Diamonds, Hearts, Spades:
+		return true
+	}
+	return false
+}
+
+// HandType returns the hand type of the rank.
+func (r HandRank) HandType() HandType {
+	tablesOnce.Do(buildTables)
+	if int(r) >= len(rankTypes) {
+		return ""
+	}
+	return rankTypes[r]
+}
+
+// buildTables evaluates one hand of every rank pattern with
+// EvaluateStrength and numbers the patterns from weakest to strongest.
+func buildTables() {
+	type pattern struct {
+		strength HandStrength
+		table    *[1 << 13]HandRank
+		key      uint32
+	}
+	var patterns []pattern
+
+	suits := []Suit{Clubs, Diamonds, Hearts, Spades}
+	ranks := NewDeck()[:13]
+	var walk func(start int, chosen []int)
+	walk = func(start int, chosen []int) {
+		if len(chosen) < 5 {
+			for r := start; r < 13; r++ {
+				walk(r, append(chosen, r))
+			}
+			return
+		}
+
+		// Give the n-th card of a rank the n-th suit
+		var hand []Trump
+		counts := make([]int, 13)
+		var bits, product uint32 = 0, 1
+		for _, r := range chosen {
+			if counts[r] == 4 {
+				return
+			}
+			hand = append(hand, Trump{Suit: suits[counts[r]], Rank: ranks[r].Rank})
+			counts[r]++
+			bits |= 1 << r
+			product *= rankPrimes[r]
+		}
+
+		if countBits(bits) == 5 {
+			// Five different ranks: all Clubs except the last card
+			hand[4].Suit = Diamonds
+			patterns = append(patterns, pattern{EvaluateStrength(hand), &unique5, bits})
+			flush := make([]Trump, 5)
+			for i, card := range hand {
+				flush[i] = Trump{Suit: Hearts, Rank: card.Rank}
+			}
+			patterns = append(patterns, pattern{EvaluateStrength(flush), &flushes, bits})
+			return
+		}
+		patterns = append(patterns, pattern{strength: EvaluateStrength(hand), key: product})
+	}
+	walk(0, nil)
+
+	sort.SliceStable(patterns, func(i, j int) bool {
+		return patterns[i].strength.Compare(patterns[j].strength) < 0
+	})
+
+	rankTypes = []HandType{""}
+	type productRank struct {
+		product uint32
+		rank    HandRank
+	}
+	var paired []productRank
+	for i, p := range patterns {
+		if i == 0 || p.strength.Compare(patterns[i-1].strength) != 0 {
+			rankTypes = append(rankTypes, p.strength.HandType)
+		}
+		rank := HandRank(len(rankTypes) - 1)
+		if p.table != nil {
+			p.table[p.key] = rank
+		} else {
+			paired = append(paired, productRank{p.key, rank})
+		}
+	}
+
+	sort.Slice(paired, func(i, j int) bool { return paired[i].product < paired[j].product })
+	for _, p := range paired {
+		products = append(products, p.product)
+		productRanks = append(productRanks, p.rank)
+	}
+}
+
+func countBits(bits uint32) int {
+	n := 0
+	for ; bits != 0; bits &= bits - 1 {
+		n++
+	}
+	return n
+}
+
+// RankBest returns the rank of the best five-card hand among 5 to 7
+// different cards, or 0 for any other hand. Unlike BestFive it does not
+// allocate, which suits simulations.
+func RankBest(hand []Trump) HandRank {
+	if len(hand) < 5 || len(hand) > 7 {
+		return 0
+	}
+	var codes [7]CardCode
+	if !encodeHand(hand, codes[:]) {
+		return 0
+	}
+	return RankBestCodes(codes[:len(hand)])
+}
+
+// RankBestCodes is RankBest for encoded cards. Like Evaluate5 it does not
+// check that the cards are different.
+func RankBestCodes(codes []CardCode) HandRank {
+	n := len(codes)
+	if n < 5 || n > 7 {
+		return 0
+	}
+	var best HandRank
+	for a := 0; a < n-4; a++ {
+		for b := a + 1; b < n-3; b++ {
+			for c := b + 1; c < n-2; c++ {
+				for d := c + 1; d < n-1; d++ {
+					for e := d + 1; e < n; e++ {
+						if r := Evaluate5(codes[a], codes[b], codes[c], codes[d], codes[e]); r > best {
+							best = r
+						}
+					}
+				}
+			}
+		}
+	}
+	return best
+}
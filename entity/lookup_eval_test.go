@@ -0,0 +1,197 @@
+package entity
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// allHands calls fn with every five-card hand of the standard deck.
+func allHands(fn func(hand []Trump, codes [5]CardCode)) {
+	deck := NewDeck()
+	var codes [5]CardCode
+	hand := make([]Trump, 5)
+	combinations(len(deck), 5, func(indexes []int) {
+		for i, index := range indexes {
+			hand[i] = deck[index]
+			codes[i] = EncodeCard(deck[index])
+		}
+		fn(hand, codes)
+	})
+}
+
+func TestEvaluate5AllHands(t *testing.T) {
+	if testing.Short() {
+		t.Skip("evaluates all 2,598,960 hands")
+	}
+
+	n := 0
+	counts := make(map[HandType]int)
+	distinct := make(map[HandRank]bool)
+	allHands(func(hand []Trump, codes [5]CardCode) {
+		n++
+		rank := Evaluate5(codes[0], codes[1], codes[2], codes[3], codes[4])
+		want := EvaluateHand(hand)
+		if rank.HandType() != want {
+			t.Fatalf("Evaluate5(%v) = %d (%s), want %s", hand, rank, rank.HandType(), want)
+		}
+		counts[want]++
+		distinct[rank] = true
+	})
+
+	if n != 2598960 {
+		t.Errorf("evaluated %d hands, want 2598960", n)
+	}
+	if len(distinct) != 7462 {
+		t.Errorf("found %d distinct ranks, want 7462", len(distinct))
+	}
+	want := map[HandType]int{
+		RoyalFlush:    4,
+		StraightFlush: 36,
+		FourOfAKind:   624,
+		FullHouse:     3744,
+		Flush:         5108,
+		Straight:      10200,
+		ThreeOfAKind:  54912,
+		TwoPair:       123552,
+		OnePair:       1098240,
+		HighCard:      1302540,
+	}
+	for handType, n := range want {
+		if counts[handType] != n {
+			t.Errorf("%s: %d hands, want %d", handType, counts[handType], n)
+		}
+	}
+}
+
+func TestRankHandOrder(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1)) // #nosec G404 -- tests do not need secure randomness
+	deck := NewDeck()
+	for i := 0; i < 20000; i++ {
+		rnd.Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
+		a, b := deck[:5], deck[5:10]
+
+		got := int(RankHand(a)) - int(RankHand(b))
+		if want := CompareHands(a, b); sign(got) != sign(want) {
+			t.Fatalf("RankHand(%v) - RankHand(%v) = %d, CompareHands() = %d", a, b, got, want)
+		}
+	}
+
+	if got := RankHand(cards(t, "7c 5d 4h 3s 2c")); got != 1 {
+		t.Errorf("RankHand(7 5 4 3 2) = %d, want 1", got)
+	}
+	if got := RankHand(cards(t, "Ah Kh Qh Jh Th")); got != 7462 {
+		t.Errorf("RankHand(royal flush) = %d, want 7462", got)
+	}
+	if got := RankHand(cards(t, "Ah Ah Qh Jh Th")); got != 0 {
+		t.Errorf("RankHand() of a duplicate card = %d, want 0", got)
+	}
+	if got := RankHand(cards(t, "Ah Ah Kd Qc Js")); got != 0 {
+		t.Errorf("RankHand() of a duplicate card in different suits = %d, want 0", got)
+	}
+	if got := RankHand(make([]Trump, 5)); got != 0 {
+		t.Errorf("RankHand() of zero cards = %d, want 0", got)
+	}
+	joker := append(cards(t, "Ah Kd Qc Js"), Trump{Rank: "Joker"})
+	if got := RankHand(joker); got != 0 {
+		t.Errorf("RankHand() with a joker = %d, want 0", got)
+	}
+}
+
+func TestRankBest(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2)) // #nosec G404 -- tests do not need secure randomness
+	deck := NewDeck()
+	for i := 0; i < 2000; i++ {
+		rnd.Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
+		hand := deck[:5+i%3]
+
+		best, err := BestFive(hand)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := RankBest(hand), RankHand(best.Cards); got != want {
+			t.Fatalf("RankBest(%v) = %d, want %d (%s)", hand, got, want, best.Strength)
+		}
+	}
+
+	if got := RankBest(deck[:8]); got != 0 {
+		t.Errorf("RankBest() of 8 cards = %d, want 0", got)
+	}
+	if got := RankBest(cards(t, "Ah Ah Kd Qc Js 2c")); got != 0 {
+		t.Errorf("RankBest() of a duplicate card = %d, want 0", got)
+	}
+	if got := RankBest(make([]Trump, 6)); got != 0 {
+		t.Errorf("RankBest() of zero cards = %d, want 0", got)
+	}
+}
+
+func BenchmarkBestFive7(b *testing.B) {
+	hands := benchmarkHands7(1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = BestFive(hands[i%len(hands)])
+	}
+}
+
+func BenchmarkRankBest7(b *testing.B) {
+	hands := benchmarkHands7(1024)
+	RankBest(hands[0])
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		RankBest(hands[i%len(hands)])
+	}
+}
+
+func benchmarkHands7(n int) [][]Trump {
+	rnd := rand.New(rand.NewSource(1)) // #nosec G404 -- benchmarks do not need secure randomness
+	deck := NewDeck()
+	hands := make([][]Trump, n)
+	for i := range hands {
+		rnd.Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
+		hands[i] = append([]Trump(nil), deck[:7]...)
+	}
+	return hands
+}
+
+func benchmarkHands(n int) [][]Trump {
+	rnd := rand.New(rand.NewSource(1)) // #nosec G404 -- benchmarks do not need secure randomness
+	deck := NewDeck()
+	hands := make([][]Trump, n)
+	for i := range hands {
+		rnd.Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
+		hands[i] = append([]Trump(nil), deck[:5]...)
+	}
+	return hands
+}
+
+func BenchmarkEvaluateHand(b *testing.B) {
+	hands := benchmarkHands(1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		EvaluateHand(hands[i%len(hands)])
+	}
+}
+
+func BenchmarkRankHand(b *testing.B) {
+	hands := benchmarkHands(1024)
+	RankHand(hands[0])
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		RankHand(hands[i%len(hands)])
+	}
+}
+
+func BenchmarkEvaluate5(b *testing.B) {
+	hands := benchmarkHands(1024)
+	codes := make([][5]CardCode, len(hands))
+	for i, hand := range hands {
+		for j, card := range hand {
+			codes[i][j] = EncodeCard(card)
+		}
+	}
+	Evaluate5(codes[0][0], codes[0][1], codes[0][2], codes[0][3], codes[0][4])
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c := &codes[i%len(codes)]
+		Evaluate5(c[0], c[1], c[2], c[3], c[4])
+	}
+}
@@ -1,9 +1,5 @@
 package entity
 
-import (
-	"sort"
-)
-
 type HandType string
 
 const (
@@ -19,8 +15,27 @@ const (
 	RoyalFlush    HandType = "Royal Flush"
 )
 
+// HandTypes returns all hand types from weakest to strongest.
+func HandTypes() []HandType {
+	return []HandType{
+		HighCard,
+		OnePair,
+		TwoPair,
+		ThreeOfAKind,
+		Straight,
+		Flush,
+		FullHouse,
+		FourOfAKind,
+		StraightFlush,
+		RoyalFlush,
+	}
+}
+
 type PokerHands struct {
 	PokerHands []PokerHand
+	// Ranking decides the hand type of played cards. Nil is the standard
+	// ranking.
+	Ranking *HandRanking
 }
 
 type PokerHand struct {
@@ -35,11 +50,12 @@ type PokerHandLevel struct {
 }
 
 type PokerHandStats struct {
-	HandType HandType
-	Level    int
-	Chip     int
-	Mult     int
-	Score    int
+	HandType     HandType `json:"hand_type"`
+	ScoringCards []Trump  `json:"scoring_cards,omitempty"`
+	Level        int      `json:"level"`
+	Chip         int      `json:"chip"`
+	Mult         int      `json:"mult"`
+	Score        int      `json:"score"`
 }
 
 func NewPokerHands() *PokerHands {
@@ -205,81 +221,45 @@ func (p *PokerHands) GetChipAndMult(HandType HandType, Level int) (Chip int, Mul
 	return 0, 0
 }
 
-// isFlush checks if all cards in the hand have the same suit.
-func isFlush(hand []Trump) bool {
-	if len(hand) < 5 {
-		return false
+// GetRanking returns the ranking hands are evaluated with.
+func (p *PokerHands) GetRanking() HandRanking {
+	if p == nil || p.Ranking == nil {
+		return standardRanking
 	}
-
-	firstSuit := hand[0].Suit
-	for _, card := range hand[1:] {
-		if card.Suit != firstSuit {
-			return false
-		}
-	}
-	return true
+	return *p.Ranking
 }
 
-// isStraight checks if the hand forms a sequential ranking.
-func isStraight(hand []Trump) bool {
-	if len(hand) != 5 {
-		return false
-	}
-
-	// Sort the hand by sort order (2, 3, 4, 5, 6, 7, 8, 9, 10, J, Q, K, A)
-	sortedHand := make([]Trump, len(hand))
-	copy(sortedHand, hand)
-	sort.Slice(sortedHand, func(i, j int) bool {
-		return sortedHand[i].GetSortOrder() < sortedHand[j].GetSortOrder()
-	})
-
-	// Check for Ace-low straight (A, 2, 3, 4, 5)
-	if sortedHand[0].Rank == Two && sortedHand[1].Rank == Three &&
-		sortedHand[2].Rank == Four && sortedHand[3].Rank == Five &&
-		sortedHand[4].Rank == Ace {
-		return true
+// GetHandStats evaluates the given cards and returns the level 1 chip, mult
+// and score they would earn. Every card's rank is added to the base chip.
+func (p *PokerHands) GetHandStats(cards []Trump) PokerHandStats {
+	handType := p.GetRanking().Evaluate(cards)
+	chip, mult := p.GetChipAndMult(handType, 1)
+	for _, card := range cards {
+		chip += card.GetRankNumber()
 	}
 
-	// Check for standard straight
-	for i := 0; i < len(sortedHand)-1; i++ {
-		rank1 := sortedHand[i].GetSortOrder()
-		rank2 := sortedHand[i+1].GetSortOrder()
-		if rank2-rank1 != 1 {
-			return false
-		}
+	return PokerHandStats{
+		HandType:     handType,
+		ScoringCards: ScoringCards(cards, handType),
+		Level:        1,
+		Chip:         chip,
+		Mult:         mult,
+		Score:        chip * mult,
 	}
-	return true
 }
 
-// isRoyalFlush checks if the hand is a Royal Flush (10, J, Q, K, A).
-func isRoyalFlush(hand []Trump) bool {
-	if len(hand) != 5 {
+// isFlush checks if all cards in the hand have the same suit.
+func isFlush(hand []Trump) bool {
+	if len(hand) < 5 {
 		return false
 	}
 
-	requiredRanks := map[Rank]bool{
-		Ten:   false,
-		Jack:  false,
-		Queen: false,
-		King:  false,
-		Ace:   false,
-	}
-
-	for _, card := range hand {
-		if _, exists := requiredRanks[card.Rank]; exists {
-			requiredRanks[card.Rank] = true
-		} else {
-			return false
-		}
-	}
-
-	// Check if all required ranks are present
-	for _, present := range requiredRanks {
-		if !present {
+	firstSuit := hand[0].Suit
+	for _, card := range hand[1:] {
+		if card.Suit != firstSuit {
 			return false
 		}
 	}
-
 	return true
 }
 
@@ -292,73 +272,45 @@ func groupByRank(hand []Trump) map[Rank]int {
 	return rankCount
 }
 
-// EvaluateHand evaluates the given hand and returns the HandType.
+// EvaluateHand evaluates the given hand with the standard ranking and
+// returns the HandType. The hand has at most 5 cards; BestFive finds the
+// best hand among more cards.
 func EvaluateHand(hand []Trump) HandType {
-	isFlush := isFlush(hand)
-	isStraight := isStraight(hand)
+	return standardRanking.Evaluate(hand)
+}
 
-	if isFlush && isStraight {
-		// Check for Royal Flush (10, J, Q, K, A)
-		if isRoyalFlush(hand) {
-			return RoyalFlush
-		}
-		return StraightFlush
+// ScoringCards returns the cards that form the hand type, e.g. the two
+// paired cards of a One Pair or the highest card of a High Card.
+func ScoringCards(hand []Trump, handType HandType) []Trump {
+	if len(hand) == 0 {
+		return nil
 	}
 
-	rankCount := groupByRank(hand)
-	var pairs, threes, fours int
-	for _, count := range rankCount {
-		switch count {
-		case 2:
-			pairs++
-		case 3:
-			threes++
-		case 4:
-			fours++
+	switch handType {
+	case HighCard:
+		highest := hand[0]
+		for _, card := range hand[1:] {
+			if card.GetSortOrder() > highest.GetSortOrder() {
+				highest = card
+			}
 		}
+		return []Trump{highest}
+	case OnePair, TwoPair, ThreeOfAKind, FourOfAKind:
+		rankCount := groupByRank(hand)
+		var cards []Trump
+		for _, card := range hand {
+			if rankCount[card.Rank] >= 2 {
+				cards = append(cards, card)
+			}
+		}
+		return cards
 	}
 
-	if fours == 1 {
-		return FourOfAKind
-	} else if threes == 1 && pairs == 1 {
-		return FullHouse
-	} else if isFlush {
-		return Flush
-	} else if isStraight {
-		return Straight
-	} else if threes == 1 {
-		return ThreeOfAKind
-	} else if pairs == 2 {
-		return TwoPair
-	} else if pairs == 1 {
-		return OnePair
-	}
-
-	return HighCard
+	return append([]Trump(nil), hand...)
 }
 
+// GetScore returns the position of the hand type in the standard ranking,
+// from 1 for High Card to 10 for Royal Flush, or 0 for an unknown hand type.
 func GetScore(hand HandType) int {
-	switch hand {
-	case HighCard:
-		return 1
-	case OnePair:
-		return 2
-	case TwoPair:
-		return 3
-	case ThreeOfAKind:
-		return 4
-	case Straight:
-		return 5
-	case Flush:
-		return 6
-	case FullHouse:
-		return 7
-	case FourOfAKind:
-		return 8
-	case StraightFlush:
-		return 9
-	case RoyalFlush:
-		return 10
-	}
-	return 0
+	return standardRanking.Score(hand)
 }
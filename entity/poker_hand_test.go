@@ -171,3 +171,86 @@ func TestPokerHandStatsCalculation(t *testing.T) {
 		})
 	}
 }
+
+func TestGetHandStats(t *testing.T) {
+	hands := NewPokerHands()
+
+	cards := []Trump{
+		{Suit: Spades, Rank: Ace},
+		{Suit: Hearts, Rank: Ace},
+		{Suit: Diamonds, Rank: Two},
+	}
+	stats := hands.GetHandStats(cards)
+
+	if stats.HandType != OnePair {
+		t.Errorf("GetHandStats() HandType = %s, want %s", stats.HandType, OnePair)
+	}
+
+	wantChip := 10 + 14 + 14 + 2
+	if stats.Chip != wantChip {
+		t.Errorf("GetHandStats() Chip = %d, want %d", stats.Chip, wantChip)
+	}
+
+	if stats.Mult != 2 {
+		t.Errorf("GetHandStats() Mult = %d, want 2", stats.Mult)
+	}
+
+	if stats.Score != wantChip*2 {
+		t.Errorf("GetHandStats() Score = %d, want %d", stats.Score, wantChip*2)
+	}
+}
+
+func TestScoringCards(t *testing.T) {
+	tests := []struct {
+		name string
+		hand []Trump
+		want []Trump
+	}{
+		{
+			name: "High Card",
+			hand: []Trump{{Suit: Spades, Rank: Two}, {Suit: Hearts, Rank: King}, {Suit: Clubs, Rank: Nine}},
+			want: []Trump{{Suit: Hearts, Rank: King}},
+		},
+		{
+			name: "One Pair",
+			hand: []Trump{{Suit: Spades, Rank: Two}, {Suit: Hearts, Rank: King}, {Suit: Clubs, Rank: Two}},
+			want: []Trump{{Suit: Spades, Rank: Two}, {Suit: Clubs, Rank: Two}},
+		},
+		{
+			name: "Two Pair",
+			hand: []Trump{
+				{Suit: Spades, Rank: Two}, {Suit: Hearts, Rank: King}, {Suit: Clubs, Rank: Two},
+				{Suit: Clubs, Rank: King}, {Suit: Clubs, Rank: Five},
+			},
+			want: []Trump{
+				{Suit: Spades, Rank: Two}, {Suit: Hearts, Rank: King}, {Suit: Clubs, Rank: Two},
+				{Suit: Clubs, Rank: King},
+			},
+		},
+		{
+			name: "Straight",
+			hand: []Trump{
+				{Suit: Spades, Rank: Two}, {Suit: Hearts, Rank: Three}, {Suit: Clubs, Rank: Four},
+				{Suit: Clubs, Rank: Five}, {Suit: Diamonds, Rank: Six},
+			},
+			want: []Trump{
+				{Suit: Spades, Rank: Two}, {Suit: Hearts, Rank: Three}, {Suit: Clubs, Rank: Four},
+				{Suit: Clubs, Rank: Five}, {Suit: Diamonds, Rank: Six},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ScoringCards(tt.hand, EvaluateHand(tt.hand))
+			if len(got) != len(tt.want) {
+				t.Fatalf("ScoringCards() returned %d cards, want %d", len(got), len(tt.want))
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("ScoringCards()[%d] = %s, want %s", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"fmt"
 	"strings"
 )
 
@@ -62,21 +63,37 @@ func (p *PokerRound) RemainCardString() []string {
 	return cards
 }
 
-func (p *PokerRound) SetSelectCards(cards []string) {
-	// Convert select cards to Trump entity
-	var selectCards []Trump
+// FindHandCards converts card strings to the matching hand cards. Cards not
+// in hand are skipped and reported in the error.
+func (p *PokerRound) FindHandCards(cards []string) ([]Trump, error) {
+	var found []Trump
+	var missing []string
 	for _, card := range cards {
 		// extract rank and suit from card string
-		rank := strings.Split(card, " of ")[0]
-		suit := strings.Split(card, " of ")[1]
+		rank, suit, _ := strings.Cut(card, " of ")
 		// Find the card from hand
+		ok := false
 		for _, t := range p.HandCards {
 			if string(t.Rank) == rank && string(t.Suit) == suit {
-				selectCards = append(selectCards, t)
+				found = append(found, t)
+				ok = true
 				break
 			}
 		}
+		if !ok {
+			missing = append(missing, card)
+		}
 	}
+
+	if len(missing) > 0 {
+		return found, fmt.Errorf("cards not in hand: %s", strings.Join(missing, ", "))
+	}
+	return found, nil
+}
+
+func (p *PokerRound) SetSelectCards(cards []string) {
+	// Convert select cards to Trump entity
+	selectCards, _ := p.FindHandCards(cards)
 	p.SelectedCards = selectCards
 
 	// Calc the RemainCards cards
@@ -105,6 +122,12 @@ func (p *PokerRound) GetRoundStats() *RoundStats {
 	return &p.Stats
 }
 
+// IsOutOfCards reports whether no cards are left in hand or in the deck
+// after a play or a discard. Small decks can run out before the hands do.
+func (p *PokerRound) IsOutOfCards() bool {
+	return len(p.RemainCards) == 0 && p.Deck.Len() == 0
+}
+
 func (p *PokerRound) IsWin() bool {
 	return p.Stats.TotalScore >= p.Stats.ScoreAtLeast
 }
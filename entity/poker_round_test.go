@@ -212,3 +212,27 @@ func TestRoundStats(t *testing.T) {
 		t.Errorf("RoundStats.Discards = %d, want 1", stats.Discards)
 	}
 }
+
+func TestPokerRoundFindHandCards(t *testing.T) {
+	round := NewPokerRound(NewDeck(), 4, 3, 300)
+	round.HandCards = []Trump{
+		{Suit: Spades, Rank: Ace},
+		{Suit: Hearts, Rank: King},
+	}
+
+	cards, err := round.FindHandCards([]string{"K of Hearts"})
+	if err != nil {
+		t.Fatalf("FindHandCards() returned error: %v", err)
+	}
+	if len(cards) != 1 || cards[0] != (Trump{Suit: Hearts, Rank: King}) {
+		t.Errorf("FindHandCards() = %v, want [K of Hearts]", cards)
+	}
+
+	cards, err = round.FindHandCards([]string{"A of Spades", "Q of Clubs", "invalid"})
+	if err == nil {
+		t.Error("FindHandCards() with cards not in hand should return error")
+	}
+	if len(cards) != 1 {
+		t.Errorf("FindHandCards() returned %d cards, want 1", len(cards))
+	}
+}
@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DefaultMaxSelectCards is the maximum number of cards in one play or discard.
+const DefaultMaxSelectCards = 5
+
+// Rules are the parameters a run is played with.
+type Rules struct {
+	Name           string
+	Deal           int
+	Hands          int
+	Discards       int
+	MaxSelectCards int
+	AnteAmounts    []int
+	BlindMultis    []float64
+	PokerHands     *PokerHands
+}
+
+// DefaultRules returns the standard rules.
+func DefaultRules() Rules {
+	return Rules{
+		Name:           "Standard",
+		Deal:           8,
+		Hands:          4,
+		Discards:       3,
+		MaxSelectCards: DefaultMaxSelectCards,
+		AnteAmounts:    DefaultAnteAmounts(),
+		BlindMultis:    DefaultBlindMultis(),
+		PokerHands:     NewPokerHands(),
+	}
+}
+
+// Validate returns all problems of the rules joined into one error.
+func (r Rules) Validate() error {
+	var errs []error
+	if r.Name == "" {
+		errs = append(errs, errors.New("name must not be empty"))
+	}
+	if r.Deal < 1 {
+		errs = append(errs, fmt.Errorf("deal must be at least 1, got %d", r.Deal))
+	}
+	if r.Hands < 1 {
+		errs = append(errs, fmt.Errorf("hands must be at least 1, got %d", r.Hands))
+	}
+	if r.Discards < 0 {
+		errs = append(errs, fmt.Errorf("discards must not be negative, got %d", r.Discards))
+	}
+	if r.MaxSelectCards < 1 || r.MaxSelectCards > r.Deal {
+		errs = append(errs, fmt.Errorf("max_select_cards must be between 1 and deal (%d), got %d", r.Deal, r.MaxSelectCards))
+	}
+	// A run is won after WinAnte antes, later antes reuse the last amount
+	if len(r.AnteAmounts) < WinAnte {
+		errs = append(errs, fmt.Errorf("ante_amounts must have at least %d entries, got %d", WinAnte, len(r.AnteAmounts)))
+	}
+	for i, amount := range r.AnteAmounts {
+		if amount < 1 {
+			errs = append(errs, fmt.Errorf("ante_amounts[%d] must be at least 1, got %d", i, amount))
+		}
+	}
+	if len(r.BlindMultis) == 0 {
+		errs = append(errs, errors.New("blind_multis must not be empty"))
+	}
+	for i, multi := range r.BlindMultis {
+		if multi <= 0 {
+			errs = append(errs, fmt.Errorf("blind_multis[%d] must be positive, got %g", i, multi))
+		}
+	}
+	if r.PokerHands == nil {
+		errs = append(errs, errors.New("poker_hands must be set"))
+	} else {
+		for _, handType := range HandTypes() {
+			if chip, mult := r.PokerHands.GetChipAndMult(handType, 1); chip < 0 || mult < 1 {
+				errs = append(errs, fmt.Errorf("poker_hands.%s level 1 needs chip >= 0 and mult >= 1, got chip %d and mult %d", handType, chip, mult))
+			}
+		}
+		if r.PokerHands.Ranking != nil {
+			if err := r.PokerHands.Ranking.Validate(); err != nil {
+				errs = append(errs, fmt.Errorf("hand_ranking: %w", err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
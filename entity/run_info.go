@@ -1,53 +1,276 @@
 package entity
 
-var AnteAmounts = []int{
-	300,
-	800,
-	2800,
-	6000,
-	11000,
-	20000,
-	35000,
-	50000,
-	110000,
-	560000,
-	7200000,
-	300000000,
-	47000000000,
-	2900 * 100000000000,
-	7700 * 1000000000000,
-	8600000000000000000,
-}
-
-var BlindMultis = []float64{
-	1.0,
-	1.5,
-	2.0,
+import (
+	cryptorand "crypto/rand"
+	"fmt"
+	"math/big"
+	"math/rand"
+)
+
+// DefaultAnteAmounts returns the base score required for each ante.
+func DefaultAnteAmounts() []int {
+	return []int{
+		300,
+		800,
+		2800,
+		6000,
+		11000,
+		20000,
+		35000,
+		50000,
+		110000,
+		560000,
+		7200000,
+		300000000,
+		47000000000,
+		2900 * 100000000000,
+		7700 * 1000000000000,
+		8600000000000000000,
+	}
+}
+
+// DefaultBlindMultis returns the score multiplier of each blind in an ante.
+func DefaultBlindMultis() []float64 {
+	return []float64{
+		1.0,
+		1.5,
+		2.0,
+	}
 }
 
+// WinAnte is the number of antes a run has to clear to be won.
+const WinAnte = 8
+
 type RunInfo struct {
+	RulesName       string
 	DefaultDeal     int
 	DefaultHands    int
 	DefaultDiscards int
+	MaxSelectCards  int
+	AnteAmounts     []int
+	BlindMultis     []float64
 	AnteIndex       int
 	BlindIndex      int
 	Deck            Deck
-	PokerHands      *PokerHands
-	Rounds          int
-	StartNext       bool
+	DeckName        string
+	// DeckID is the ID of a pack deck, empty for built-in decks.
+	DeckID     string
+	Stake      Stake
+	PokerHands *PokerHands
+	Jokers     []Joker
+	Rounds     int
+	StartNext  bool
+	HintsUsed  bool
+	Stats      RunStats
+	// Challenge is the challenge being played, if any.
+	Challenge *Challenge
+	// BossBlinds are the boss blinds the last blind of an ante is chosen
+	// from. Boss is the current one, if any.
+	BossBlinds []BossBlind
+	Boss       *BossBlind
+	// Rand shuffles the deck when set, otherwise crypto/rand is used.
+	Rand *rand.Rand
 }
 
 func NewRunInfo() *RunInfo {
+	return NewRunInfoWithRules(DefaultRules())
+}
+
+// NewRunInfoWithRules returns a run played with the given rules.
+func NewRunInfoWithRules(rules Rules) *RunInfo {
 	return &RunInfo{
-		DefaultDeal:     8,
-		DefaultHands:    4,
-		DefaultDiscards: 3,
-		Deck:            NewDeck(),
-		PokerHands:      NewPokerHands(),
+		RulesName:       rules.Name,
+		DefaultDeal:     rules.Deal,
+		DefaultHands:    rules.Hands,
+		DefaultDiscards: rules.Discards,
+		MaxSelectCards:  rules.MaxSelectCards,
+		AnteAmounts:     append([]int(nil), rules.AnteAmounts...),
+		BlindMultis:     append([]float64(nil), rules.BlindMultis...),
+		Deck:            rules.PokerHands.GetRanking().NewDeck(),
+		DeckName:        StandardDeck().Name,
+		Stake:           WhiteStake(),
+		PokerHands:      rules.PokerHands,
 		Rounds:          1,
 		StartNext:       true,
 		AnteIndex:       0,
 		BlindIndex:      0,
+		Stats:           RunStats{HandTypes: make(map[HandType]int)},
+	}
+}
+
+// RunStats accumulates the plays of a run.
+type RunStats struct {
+	HandTypes      map[HandType]int
+	BestHandScore  int
+	CardsDiscarded int
+}
+
+// UseDeck starts the run with the given deck instead of the standard one.
+// Cards the hand ranking leaves out of the deck are removed.
+func (r *RunInfo) UseDeck(deck StartingDeck) {
+	r.Deck = r.PokerHands.GetRanking().Filter(deck.NewDeck())
+	r.DeckName = deck.Name
+	r.DeckID = deck.ID
+	r.DefaultHands += deck.ExtraHands
+	r.DefaultDiscards += deck.ExtraDiscards
+	r.Jokers = append(r.Jokers, deck.Jokers...)
+}
+
+// UseChallenge plays the run as the challenge. The rules of the challenge
+// must already be in use.
+func (r *RunInfo) UseChallenge(c Challenge) {
+	r.UseDeck(c.Deck)
+	r.Jokers = append(r.Jokers, c.Jokers...)
+	r.Challenge = &c
+}
+
+// UseStake plays the run at the given stake.
+func (r *RunInfo) UseStake(stake Stake) {
+	r.Stake = stake
+	r.DefaultDiscards += stake.ExtraDiscards
+	if r.DefaultDiscards < 0 {
+		r.DefaultDiscards = 0
+	}
+}
+
+// AnteAmount returns the base score of the current ante. Antes beyond
+// AnteAmounts use the last amount.
+func (r *RunInfo) AnteAmount() int {
+	if r.AnteIndex >= len(r.AnteAmounts) {
+		return r.AnteAmounts[len(r.AnteAmounts)-1]
+	}
+	return r.AnteAmounts[r.AnteIndex]
+}
+
+// ScoreAtLeast returns the score required to clear the current blind.
+func (r *RunInfo) ScoreAtLeast() int {
+	score := float64(r.AnteAmount()) * r.BlindMultis[r.BlindIndex] * r.Stake.ScoreMulti
+	if r.Boss != nil && r.Boss.ScoreMulti != 0 {
+		score *= r.Boss.ScoreMulti
+	}
+	return int(score)
+}
+
+// ChooseBoss picks the boss blind of the current blind. Only the last blind
+// of an ante has a boss, if there are any boss blinds.
+func (r *RunInfo) ChooseBoss() {
+	r.Boss = nil
+	if len(r.BossBlinds) == 0 || r.BlindIndex != len(r.BlindMultis)-1 {
+		return
+	}
+	boss := r.BossBlinds[r.intn(len(r.BossBlinds))]
+	r.Boss = &boss
+}
+
+// RoundHands returns the number of hands in the current round.
+func (r *RunInfo) RoundHands() int {
+	hands := r.DefaultHands
+	if r.Boss != nil {
+		hands += r.Boss.ExtraHands
+	}
+	if hands < 1 {
+		hands = 1
+	}
+	return hands
+}
+
+// RoundDiscards returns the number of discards in the current round.
+func (r *RunInfo) RoundDiscards() int {
+	discards := r.DefaultDiscards
+	if r.Boss != nil {
+		discards += r.Boss.ExtraDiscards
+	}
+	if discards < 0 {
+		discards = 0
+	}
+	return discards
+}
+
+// ScoreHand returns the stats of the cards played in the current round.
+// Cards debuffed by the boss blind score no chips, and the jokers and then
+// the hooks of the boss blind are applied in order. Hands the challenge does
+// not allow score nothing.
+func (r *RunInfo) ScoreHand(cards []Trump) (PokerHandStats, error) {
+	stats := r.PokerHands.GetHandStats(cards)
+	if !r.Challenge.Scores(stats.HandType) {
+		stats.Chip, stats.Mult, stats.Score = 0, 0, 0
+		return stats, nil
+	}
+	for _, card := range cards {
+		if r.Boss.IsDebuffed(card) {
+			stats.Chip -= card.GetRankNumber()
+		}
+	}
+	stats.Score = stats.Chip * stats.Mult
+
+	for _, joker := range r.Jokers {
+		joker.Apply(&stats, r.Boss.IsDebuffed)
+		if err := scoreHooks(joker.Hooks, &stats, r.Boss.IsDebuffed); err != nil {
+			return stats, fmt.Errorf("joker %s: %w", joker.Name, err)
+		}
+	}
+	if r.Boss != nil {
+		if err := scoreHooks(r.Boss.Hooks, &stats, r.Boss.IsDebuffed); err != nil {
+			return stats, fmt.Errorf("boss blind %s: %w", r.Boss.Name, err)
+		}
+	}
+	return stats, nil
+}
+
+// Discard calls the discard hooks of the jokers and the boss blind.
+func (r *RunInfo) Discard(cards []Trump) error {
+	r.Stats.CardsDiscarded += len(cards)
+	return r.eachHooks(func(hooks Hooks) error {
+		if err := hooks.OnDiscard(cards); err != nil {
+			return fmt.Errorf("on_discard: %w", err)
+		}
+		return nil
+	})
+}
+
+// EndRound calls the round end hooks of the jokers and the boss blind.
+func (r *RunInfo) EndRound(stats RoundStats) error {
+	return r.eachHooks(func(hooks Hooks) error {
+		if err := hooks.OnRoundEnd(stats); err != nil {
+			return fmt.Errorf("on_round_end: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *RunInfo) eachHooks(call func(Hooks) error) error {
+	for _, joker := range r.Jokers {
+		if joker.Hooks == nil {
+			continue
+		}
+		if err := call(joker.Hooks); err != nil {
+			return fmt.Errorf("joker %s: %w", joker.Name, err)
+		}
+	}
+	if r.Boss != nil && r.Boss.Hooks != nil {
+		if err := call(r.Boss.Hooks); err != nil {
+			return fmt.Errorf("boss blind %s: %w", r.Boss.Name, err)
+		}
+	}
+	return nil
+}
+
+func (r *RunInfo) intn(n int) int {
+	if r.Rand != nil {
+		return r.Rand.Intn(n)
+	}
+	i, err := cryptorand.Int(cryptorand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0
+	}
+	return int(i.Int64())
+}
+
+// RecordPlay adds a played hand to the run stats.
+func (r *RunInfo) RecordPlay(stats PokerHandStats) {
+	r.Stats.HandTypes[stats.HandType]++
+	if stats.Score > r.Stats.BestHandScore {
+		r.Stats.BestHandScore = stats.Score
 	}
 }
 
@@ -57,6 +280,7 @@ func (r *RunInfo) UnsetStartNext() {
 
 func (r *RunInfo) NextRound() error {
 	r.Rounds += 1
+	r.Boss = nil
 	if err := r.NextBlind(); err != nil {
 		return err
 	}
@@ -67,7 +291,7 @@ func (r *RunInfo) NextRound() error {
 
 func (r *RunInfo) NextBlind() error {
 	r.BlindIndex += 1
-	if r.BlindIndex >= len(BlindMultis) {
+	if r.BlindIndex >= len(r.BlindMultis) {
 		r.BlindIndex = 0
 		if err := r.NextAnte(); err != nil {
 			return err
@@ -80,3 +304,23 @@ func (r *RunInfo) NextAnte() error {
 	r.AnteIndex += 1
 	return nil
 }
+
+// Ante returns the number of the ante being played, from 1. Once the run is
+// won it stays at WinAnte, the last ante played.
+func (r *RunInfo) Ante() int {
+	return min(r.AnteIndex+1, WinAnte)
+}
+
+// IsWon reports whether the run has cleared WinAnte antes.
+func (r *RunInfo) IsWon() bool {
+	return r.AnteIndex >= WinAnte
+}
+
+// ShuffleDeck shuffles the deck with Rand, or with crypto/rand if Rand is nil.
+func (r *RunInfo) ShuffleDeck(deck Deck) {
+	if r.Rand != nil {
+		deck.ShuffleWith(r.Rand)
+		return
+	}
+	deck.Shuffle()
+}
@@ -0,0 +1,149 @@
+package entity
+
+import "testing"
+
+func TestRunInfoAnte(t *testing.T) {
+	r := NewRunInfo()
+	if r.Ante() != 1 {
+		t.Errorf("Ante() = %d, want 1", r.Ante())
+	}
+	r.AnteIndex = WinAnte
+	if !r.IsWon() || r.Ante() != WinAnte {
+		t.Errorf("IsWon(), Ante() = %v, %d, want true, %d", r.IsWon(), r.Ante(), WinAnte)
+	}
+}
+
+func TestRunInfoUseStake(t *testing.T) {
+	r := NewRunInfo()
+	if r.ScoreAtLeast() != 300 {
+		t.Errorf("ScoreAtLeast() = %d, want 300", r.ScoreAtLeast())
+	}
+
+	gold, err := FindStake("gold")
+	if err != nil {
+		t.Fatalf("FindStake() returned error: %v", err)
+	}
+	r.UseStake(gold)
+	r.BlindIndex = 1
+
+	if r.DefaultDiscards != 2 {
+		t.Errorf("DefaultDiscards = %d, want 2", r.DefaultDiscards)
+	}
+	if r.ScoreAtLeast() != 675 {
+		t.Errorf("ScoreAtLeast() = %d, want 675", r.ScoreAtLeast())
+	}
+
+	if _, err := FindStake("Purple"); err == nil {
+		t.Error("FindStake() of an unknown stake should return error")
+	}
+}
+
+func TestRunInfoBossBlind(t *testing.T) {
+	r := NewRunInfo()
+	r.BossBlinds = []BossBlind{{
+		Name:          "The Club",
+		ScoreMulti:    2,
+		ExtraDiscards: -1,
+		Debuff:        &CardCondition{Suit: Clubs},
+	}}
+
+	r.ChooseBoss()
+	if r.Boss != nil {
+		t.Fatal("the small blind should not have a boss")
+	}
+
+	r.BlindIndex = len(r.BlindMultis) - 1
+	r.ChooseBoss()
+	if r.Boss == nil || r.Boss.Name != "The Club" {
+		t.Fatalf("Boss = %v, want The Club", r.Boss)
+	}
+	if r.ScoreAtLeast() != 1200 {
+		t.Errorf("ScoreAtLeast() = %d, want 1200", r.ScoreAtLeast())
+	}
+	if r.RoundDiscards() != 2 {
+		t.Errorf("RoundDiscards() = %d, want 2", r.RoundDiscards())
+	}
+
+	// The debuffed King of Clubs scores no chips
+	stats, _ := r.ScoreHand([]Trump{{Clubs, King}, {Hearts, King}})
+	if stats.HandType != OnePair || stats.Chip != 23 {
+		t.Errorf("HandType, Chip = %s, %d, want One Pair, 23", stats.HandType, stats.Chip)
+	}
+}
+
+type countHooks struct {
+	cards, discards, rounds int
+}
+
+func (h *countHooks) OnCardScored(card Trump, stats *PokerHandStats) error {
+	h.cards++
+	stats.Mult++
+	return nil
+}
+
+func (h *countHooks) OnHandScored(stats *PokerHandStats) error { return nil }
+
+func (h *countHooks) OnDiscard(cards []Trump) error {
+	h.discards += len(cards)
+	return nil
+}
+
+func (h *countHooks) OnRoundEnd(stats RoundStats) error {
+	h.rounds++
+	return nil
+}
+
+func TestRunInfoHooks(t *testing.T) {
+	hooks := &countHooks{}
+	r := NewRunInfo()
+	r.Jokers = []Joker{{Name: "Counter", Hooks: hooks}}
+	r.Boss = &BossBlind{Name: "The Club", Debuff: &CardCondition{Suit: Clubs}}
+
+	// The debuffed King of Clubs does not trigger the hook
+	stats, err := r.ScoreHand([]Trump{{Clubs, King}, {Hearts, King}})
+	if err != nil {
+		t.Fatalf("ScoreHand() returned error: %v", err)
+	}
+	if hooks.cards != 1 || stats.Mult != 3 || stats.Score != stats.Chip*3 {
+		t.Errorf("cards, Mult, Score = %d, %d, %d, want 1, 3, Chip*3", hooks.cards, stats.Mult, stats.Score)
+	}
+
+	if err := r.Discard([]Trump{{Clubs, Two}}); err != nil {
+		t.Fatalf("Discard() returned error: %v", err)
+	}
+	if err := r.EndRound(RoundStats{}); err != nil {
+		t.Fatalf("EndRound() returned error: %v", err)
+	}
+	if hooks.discards != 1 || hooks.rounds != 1 || r.Stats.CardsDiscarded != 1 {
+		t.Errorf("discards, rounds, CardsDiscarded = %d, %d, %d, want 1, 1, 1", hooks.discards, hooks.rounds, r.Stats.CardsDiscarded)
+	}
+}
+
+func TestRunInfoChallenge(t *testing.T) {
+	r := NewRunInfo()
+	r.UseChallenge(Challenge{
+		ID:        "flush-fever",
+		Name:      "Flush Fever",
+		Deck:      StandardDeck(),
+		Jokers:    []Joker{{Name: "Plus", Effects: []Effect{{Mult: 4}}}},
+		OnlyHands: []HandType{Flush},
+	})
+
+	if r.Challenge == nil || r.DeckName != "Standard" || len(r.Jokers) != 1 {
+		t.Fatalf("Challenge, DeckName, Jokers = %v, %q, %v", r.Challenge, r.DeckName, r.Jokers)
+	}
+
+	pair, _ := r.ScoreHand([]Trump{{Clubs, King}, {Hearts, King}})
+	if pair.Score != 0 {
+		t.Errorf("One Pair scored %d, want 0", pair.Score)
+	}
+	flush, _ := r.ScoreHand([]Trump{{Hearts, Two}, {Hearts, Four}, {Hearts, Six}, {Hearts, Eight}, {Hearts, King}})
+	if flush.HandType != Flush || flush.Score == 0 {
+		t.Errorf("HandType, Score = %s, %d, want a scoring Flush", flush.HandType, flush.Score)
+	}
+
+	var none *Challenge
+	if !none.Scores(OnePair) {
+		t.Error("Every hand should score outside of a challenge")
+	}
+}
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Stake is a difficulty level a run can be played at.
+type Stake struct {
+	Name        string
+	Description string
+	// ScoreMulti multiplies the score required to clear every blind.
+	ScoreMulti    float64
+	ExtraDiscards int
+}
+
+// WhiteStake returns the stake used when no other stake is chosen.
+func WhiteStake() Stake {
+	return Stake{Name: "White", Description: "Base difficulty", ScoreMulti: 1.0}
+}
+
+// Stakes returns all built-in stakes from the easiest.
+func Stakes() []Stake {
+	return []Stake{
+		WhiteStake(),
+		{Name: "Red", Description: "-1 discard every round", ScoreMulti: 1.0, ExtraDiscards: -1},
+		{Name: "Green", Description: "Required score x1.5", ScoreMulti: 1.5},
+		{Name: "Gold", Description: "-1 discard every round and required score x1.5", ScoreMulti: 1.5, ExtraDiscards: -1},
+	}
+}
+
+// FindStake returns the built-in stake with the given name, ignoring case.
+func FindStake(name string) (Stake, error) {
+	var names []string
+	for _, s := range Stakes() {
+		if strings.EqualFold(s.Name, name) {
+			return s, nil
+		}
+		names = append(names, s.Name)
+	}
+	return Stake{}, fmt.Errorf("unknown stake %q (available: %s)", name, strings.Join(names, ", "))
+}
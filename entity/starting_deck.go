@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StartingDeck is a deck a run can be started with. Besides its cards a deck
+// may give extra hands or discards for every round.
+type StartingDeck struct {
+	// ID is the namespaced ID of a pack deck, like hearts:red-hearts.
+	// Built-in decks are found by their name and have none.
+	ID            string
+	Name          string
+	Description   string
+	ExtraHands    int
+	ExtraDiscards int
+	// Cards overrides the standard 52 cards when set.
+	Cards []Trump
+	// Jokers are in play from the start of the run.
+	Jokers []Joker
+}
+
+// StandardDeck returns the deck used when no other deck is chosen.
+func StandardDeck() StartingDeck {
+	return StartingDeck{Name: "Standard", Description: "The standard 52 cards"}
+}
+
+// StartingDecks returns all built-in starting decks.
+func StartingDecks() []StartingDeck {
+	return []StartingDeck{
+		StandardDeck(),
+		{Name: "Red", Description: "+1 discard every round", ExtraDiscards: 1},
+		{Name: "Blue", Description: "+1 hand every round", ExtraHands: 1},
+		{Name: "Abandoned", Description: "No face cards", Cards: filterDeck(func(t Trump) bool {
+			return t.Rank != Jack && t.Rank != Queen && t.Rank != King
+		})},
+	}
+}
+
+// FindStartingDeck returns the built-in deck with the given name, ignoring case.
+func FindStartingDeck(name string) (StartingDeck, error) {
+	var names []string
+	for _, d := range StartingDecks() {
+		if strings.EqualFold(d.Name, name) {
+			return d, nil
+		}
+		names = append(names, d.Name)
+	}
+	return StartingDeck{}, fmt.Errorf("unknown deck %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+// NewDeck returns a fresh copy of the cards of the deck.
+func (d StartingDeck) NewDeck() Deck {
+	if d.Cards == nil {
+		return NewDeck()
+	}
+	return append(Deck(nil), d.Cards...)
+}
+
+func filterDeck(keep func(Trump) bool) []Trump {
+	var cards []Trump
+	for _, card := range NewDeck() {
+		if keep(card) {
+			cards = append(cards, card)
+		}
+	}
+	return cards
+}
@@ -1,6 +1,11 @@
 package entity
 
-import "sort"
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
 
 type Suit string
 type Rank string
@@ -29,14 +34,69 @@ const (
 )
 
 type Trump struct {
-	Suit Suit
-	Rank Rank
+	Suit Suit `json:"suit"`
+	Rank Rank `json:"rank"`
 }
 
 func (t Trump) String() string {
 	return string(t.Rank) + " of " + string(t.Suit)
 }
 
+var suitLetters = map[Suit]string{Clubs: "c", Diamonds: "d", Hearts: "h", Spades: "s"}
+
+// Notation returns the card as its rank and suit letter, like "Ah" or "Tc",
+// the form ParseCard reads.
+func (t Trump) Notation() string {
+	return string(t.Rank) + suitLetters[t.Suit]
+}
+
+// ParseCard reads a card written as a rank and a suit letter, like "Ah",
+// "Tc" or "10c", ignoring case.
+func ParseCard(s string) (Trump, error) {
+	name := strings.ToUpper(s)
+	if len(name) < 2 {
+		return Trump{}, fmt.Errorf("invalid card %q: want a rank and a suit letter, like Ah", s)
+	}
+	rank, suit := name[:len(name)-1], strings.ToLower(name[len(name)-1:])
+	if rank == "10" {
+		rank = string(Ten)
+	}
+
+	card := Trump{Rank: Rank(rank)}
+	if card.GetRankNumber() == 0 {
+		return Trump{}, fmt.Errorf("invalid card %q: unknown rank %q (2-9, T, J, Q, K, A)", s, s[:len(s)-1])
+	}
+	for st, letter := range suitLetters {
+		if letter == suit {
+			card.Suit = st
+			return card, nil
+		}
+	}
+	return Trump{}, fmt.Errorf("invalid card %q: unknown suit %q (c, d, h, s)", s, s[len(s)-1:])
+}
+
+// ParseCards reads cards written one after another, like "AhKh", "Ah Kh" or
+// "Ah,Kh".
+func ParseCards(s string) ([]Trump, error) {
+	var cards []Trump
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool { return unicode.IsSpace(r) || r == ',' }) {
+		for field != "" {
+			// A card ends at the first suit letter
+			end := strings.IndexAny(strings.ToLower(field), "cdhs")
+			if end < 0 {
+				end = len(field) - 1
+			}
+			card, err := ParseCard(field[:end+1])
+			if err != nil {
+				return nil, err
+			}
+			cards = append(cards, card)
+			field = field[end+1:]
+		}
+	}
+	return cards, nil
+}
+
 func (t Trump) GetRankNumber() int {
 	switch t.Rank {
 	case Two:
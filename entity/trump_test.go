@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -112,3 +113,45 @@ func TestTrumpGetSortOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestParseCard(t *testing.T) {
+	tests := []struct {
+		s    string
+		want Trump
+	}{
+		{"Ah", Trump{Suit: Hearts, Rank: Ace}},
+		{"tc", Trump{Suit: Clubs, Rank: Ten}},
+		{"10D", Trump{Suit: Diamonds, Rank: Ten}},
+		{"2s", Trump{Suit: Spades, Rank: Two}},
+	}
+	for _, tt := range tests {
+		got, err := ParseCard(tt.s)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseCard(%q) = %v, %v, want %v", tt.s, got, err, tt.want)
+		}
+		if tt.s != "10D" && !strings.EqualFold(got.Notation(), tt.s) {
+			t.Errorf("Notation() = %q, want %q", got.Notation(), tt.s)
+		}
+	}
+
+	for s, want := range map[string]string{"A": "want a rank", "1h": "unknown rank", "Ax": "unknown suit", "Ahh": "unknown rank"} {
+		if _, err := ParseCard(s); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseCard(%q) error = %v, want it to mention %q", s, err, want)
+		}
+	}
+}
+
+func TestParseCards(t *testing.T) {
+	for _, s := range []string{"AhKh10c", "Ah Kh Tc", "ah,kh,tc"} {
+		got, err := ParseCards(s)
+		if err != nil || len(got) != 3 || got[0].Notation() != "Ah" || got[1].Notation() != "Kh" || got[2].Notation() != "Tc" {
+			t.Errorf("ParseCards(%q) = %v, %v", s, got, err)
+		}
+	}
+	if got, err := ParseCards(""); err != nil || len(got) != 0 {
+		t.Errorf("ParseCards(\"\") = %v, %v", got, err)
+	}
+	if _, err := ParseCards("AhKx"); err == nil {
+		t.Error("ParseCards(AhKx) did not fail")
+	}
+}
@@ -0,0 +1,306 @@
+// Package equity computes how often hold'em hands win against each other on
+// the boards that can still come.
+package equity
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"github.com/litencatt/pkr/entity"
+)
+
+// DefaultMaxBoards is the number of boards up to which every board is
+// enumerated instead of sampled. It covers the 1,712,304 boards of two hands
+// before the flop.
+const DefaultMaxBoards = 2000000
+
+// trialsPerJob is the number of boards one job samples. Job i is seeded
+// with Seed+i, so the result does not depend on the number of workers.
+const trialsPerJob = 1000
+
+// ErrDuplicateCard is returned when a card is dealt twice.
+var ErrDuplicateCard = errors.New("card is dealt more than once")
+
+// Config is the configuration of an equity calculation.
+type Config struct {
+	// Hands are the two hole cards of every player.
+	Hands [][]entity.Trump
+	// Board is the 0 to 5 community cards already dealt.
+	Board []entity.Trump
+	// MaxBoards is the number of boards up to which all of them are
+	// enumerated, DefaultMaxBoards if zero. Above it Trials random boards
+	// are sampled.
+	MaxBoards int
+	Trials    int
+	Seed      int64
+	Workers   int
+}
+
+// HandResult is how often a hand won, tied and how much of the pot it won.
+type HandResult struct {
+	Cards []entity.Trump
+	Wins  int
+	Ties  int
+	// Share is the number of pots won, counting a pot split n ways as 1/n.
+	Share float64
+}
+
+// Result is the outcome of an equity calculation.
+type Result struct {
+	Hands []HandResult
+	// Boards is the number of boards evaluated.
+	Boards int
+	// Exhaustive is true when every board was evaluated and false when
+	// boards were sampled.
+	Exhaustive bool
+}
+
+// Win returns the ratio of boards the hand won alone.
+func (r *Result) Win(i int) float64 {
+	return r.ratio(float64(r.Hands[i].Wins))
+}
+
+// Tie returns the ratio of boards the hand split.
+func (r *Result) Tie(i int) float64 {
+	return r.ratio(float64(r.Hands[i].Ties))
+}
+
+// Equity returns the share of the pot the hand wins on average.
+func (r *Result) Equity(i int) float64 {
+	return r.ratio(r.Hands[i].Share)
+}
+
+func (r *Result) ratio(n float64) float64 {
+	if r.Boards == 0 {
+		return 0
+	}
+	return n / float64(r.Boards)
+}
+
+func (r *Result) add(t *tally) {
+	r.Boards += t.boards
+	for i := range r.Hands {
+		r.Hands[i].Wins += t.wins[i]
+		r.Hands[i].Ties += t.ties[i]
+		r.Hands[i].Share += t.share[i]
+	}
+}
+
+// Calculate evaluates the hands on every board that can come, or on
+// cfg.Trials random boards when there are more than cfg.MaxBoards.
+func Calculate(cfg Config) (*Result, error) {
+	if len(cfg.Hands) < 2 {
+		return nil, errors.New("at least two hands are needed")
+	}
+	if len(cfg.Board) > 5 {
+		return nil, fmt.Errorf("the board has at most 5 cards, got %d", len(cfg.Board))
+	}
+	if cfg.Workers <= 0 {
+		return nil, errors.New("workers must be positive")
+	}
+
+	seen := make(map[entity.Trump]bool)
+	deal := func(card entity.Trump) error {
+		if seen[card] {
+			return fmt.Errorf("%s: %w", card.Notation(), ErrDuplicateCard)
+		}
+		seen[card] = true
+		return nil
+	}
+	for i, hand := range cfg.Hands {
+		if len(hand) != 2 {
+			return nil, fmt.Errorf("hand %d has %d cards, want 2", i+1, len(hand))
+		}
+		for _, card := range hand {
+			if err := deal(card); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, card := range cfg.Board {
+		if err := deal(card); err != nil {
+			return nil, err
+		}
+	}
+
+	var rest []entity.CardCode
+	for _, card := range entity.NewDeck() {
+		if !seen[card] {
+			rest = append(rest, entity.EncodeCard(card))
+		}
+	}
+
+	missing := 5 - len(cfg.Board)
+	if len(rest) < missing {
+		return nil, fmt.Errorf("%d cards are left for %d more board cards", len(rest), missing)
+	}
+
+	maxBoards := cfg.MaxBoards
+	if maxBoards == 0 {
+		maxBoards = DefaultMaxBoards
+	}
+
+	e := newEvaluation(cfg)
+	if boards(len(rest), missing) <= maxBoards {
+		e.Exhaustive = true
+		e.run(cfg.Workers, max(len(rest), 1), func(t *tally, job int) {
+			t.enumerate(rest, job, missing)
+		})
+		return e.Result, nil
+	}
+
+	if cfg.Trials <= 0 {
+		return nil, errors.New("trials must be positive")
+	}
+	jobs := (cfg.Trials + trialsPerJob - 1) / trialsPerJob
+	e.run(cfg.Workers, jobs, func(t *tally, job int) {
+		n := min(trialsPerJob, cfg.Trials-job*trialsPerJob)
+		rnd := rand.New(rand.NewSource(cfg.Seed + int64(job))) // #nosec G404 -- equity sampling does not need secure randomness
+		t.sample(rest, missing, n, rnd)
+	})
+	return e.Result, nil
+}
+
+// boards returns the number of ways to choose k of n cards.
+func boards(n, k int) int {
+	result := 1
+	for i := 0; i < k; i++ {
+		result = result * (n - i) / (i + 1)
+	}
+	return result
+}
+
+// evaluation runs jobs on a pool of workers and adds up their tallies.
+type evaluation struct {
+	*Result
+	holes [][2]entity.CardCode
+	board []entity.CardCode
+	mu    sync.Mutex
+}
+
+func newEvaluation(cfg Config) *evaluation {
+	e := &evaluation{Result: &Result{}}
+	for _, hand := range cfg.Hands {
+		e.Hands = append(e.Hands, HandResult{Cards: hand})
+		e.holes = append(e.holes, [2]entity.CardCode{entity.EncodeCard(hand[0]), entity.EncodeCard(hand[1])})
+	}
+	for _, card := range cfg.Board {
+		e.board = append(e.board, entity.EncodeCard(card))
+	}
+	return e
+}
+
+func (e *evaluation) run(workers, jobs int, work func(t *tally, job int)) {
+	queue := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			t := e.newTally()
+			for job := range queue {
+				work(t, job)
+			}
+
+			e.mu.Lock()
+			e.add(t)
+			e.mu.Unlock()
+		}()
+	}
+
+	for job := 0; job < jobs; job++ {
+		queue <- job
+	}
+	close(queue)
+	wg.Wait()
+}
+
+// tally counts the results of the boards one worker evaluated.
+type tally struct {
+	e      *evaluation
+	boards int
+	wins   []int
+	ties   []int
+	share  []float64
+	// cards are the seven cards of a player: hole cards, board and the
+	// cards still to come
+	cards [7]entity.CardCode
+	ranks []entity.HandRank
+}
+
+func (e *evaluation) newTally() *tally {
+	n := len(e.holes)
+	t := &tally{e: e, wins: make([]int, n), ties: make([]int, n), share: make([]float64, n), ranks: make([]entity.HandRank, n)}
+	copy(t.cards[2:], e.board)
+	return t
+}
+
+// enumerate evaluates every board whose first missing card is rest[first].
+// With no missing cards, only job 0 evaluates the board.
+func (t *tally) enumerate(rest []entity.CardCode, first, missing int) {
+	if missing == 0 {
+		if first == 0 {
+			t.evaluate()
+		}
+		return
+	}
+	at := 2 + len(t.e.board)
+	t.cards[at] = rest[first]
+	t.choose(rest, first+1, at+1, missing-1)
+}
+
+func (t *tally) choose(rest []entity.CardCode, from, at, missing int) {
+	if missing == 0 {
+		t.evaluate()
+		return
+	}
+	for i := from; i <= len(rest)-missing; i++ {
+		t.cards[at] = rest[i]
+		t.choose(rest, i+1, at+1, missing-1)
+	}
+}
+
+// sample evaluates n random boards.
+func (t *tally) sample(rest []entity.CardCode, missing, n int, rnd *rand.Rand) {
+	deck := append([]entity.CardCode(nil), rest...)
+	at := 2 + len(t.e.board)
+	for trial := 0; trial < n; trial++ {
+		for i := 0; i < missing; i++ {
+			j := i + rnd.Intn(len(deck)-i)
+			deck[i], deck[j] = deck[j], deck[i]
+			t.cards[at+i] = deck[i]
+		}
+		t.evaluate()
+	}
+}
+
+// evaluate scores the board in cards for every hand.
+func (t *tally) evaluate() {
+	var best entity.HandRank
+	winners := 0
+	for i, hand := range t.e.holes {
+		t.cards[0], t.cards[1] = hand[0], hand[1]
+		t.ranks[i] = entity.RankBestCodes(t.cards[:])
+		switch {
+		case t.ranks[i] > best:
+			best, winners = t.ranks[i], 1
+		case t.ranks[i] == best:
+			winners++
+		}
+	}
+
+	t.boards++
+	for i, rank := range t.ranks {
+		if rank != best {
+			continue
+		}
+		if winners == 1 {
+			t.wins[i]++
+		} else {
+			t.ties[i]++
+		}
+		t.share[i] += 1 / float64(winners)
+	}
+}
@@ -0,0 +1,187 @@
+package equity
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/litencatt/pkr/entity"
+)
+
+func cards(t *testing.T, s string) []entity.Trump {
+	t.Helper()
+	c, err := entity.ParseCards(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func config(t *testing.T, board string, hands ...string) Config {
+	t.Helper()
+	cfg := Config{Board: cards(t, board), Trials: 20000, Seed: 1, Workers: 4}
+	for _, h := range hands {
+		cfg.Hands = append(cfg.Hands, cards(t, h))
+	}
+	return cfg
+}
+
+// naive counts the wins and ties of every river on a four-card board.
+func naive(t *testing.T, cfg Config) (wins, ties []int, boards int) {
+	t.Helper()
+	wins, ties = make([]int, len(cfg.Hands)), make([]int, len(cfg.Hands))
+	for _, river := range entity.NewDeck() {
+		board := append(append([]entity.Trump(nil), cfg.Board...), river)
+		if dealt(cfg, river) {
+			continue
+		}
+		boards++
+		ranks := make([]entity.HandRank, len(cfg.Hands))
+		var best entity.HandRank
+		for i, hand := range cfg.Hands {
+			ranks[i] = entity.RankBest(append(append([]entity.Trump(nil), hand...), board...))
+			best = max(best, ranks[i])
+		}
+		winners := 0
+		for _, r := range ranks {
+			if r == best {
+				winners++
+			}
+		}
+		for i, r := range ranks {
+			if r == best && winners == 1 {
+				wins[i]++
+			} else if r == best {
+				ties[i]++
+			}
+		}
+	}
+	return wins, ties, boards
+}
+
+func dealt(cfg Config, card entity.Trump) bool {
+	for _, hand := range cfg.Hands {
+		if entity.Contains(hand, card) {
+			return true
+		}
+	}
+	return entity.Contains(cfg.Board, card)
+}
+
+func TestCalculateExhaustive(t *testing.T) {
+	cfg := config(t, "2h7h9c3d", "AhKh", "QsQd", "9s8s")
+	result, err := Calculate(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Exhaustive {
+		t.Error("44 rivers were sampled")
+	}
+
+	wins, ties, boards := naive(t, cfg)
+	if result.Boards != boards {
+		t.Errorf("Boards = %d, want %d", result.Boards, boards)
+	}
+	for i, h := range result.Hands {
+		if h.Wins != wins[i] || h.Ties != ties[i] {
+			t.Errorf("hand %d: %d wins and %d ties, want %d and %d", i, h.Wins, h.Ties, wins[i], ties[i])
+		}
+	}
+	total := 0.0
+	for i := range result.Hands {
+		total += result.Equity(i)
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Errorf("equities add up to %f", total)
+	}
+}
+
+func TestCalculateFlop(t *testing.T) {
+	// On the flop the flush draw and overcards are about even with the pair
+	result, err := Calculate(config(t, "2h7h9c", "AhKh", "QsQd"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Exhaustive || result.Boards != 990 {
+		t.Fatalf("Boards = %d, exhaustive %v, want all 990", result.Boards, result.Exhaustive)
+	}
+	if w := result.Win(0); w < 0.45 || w > 0.55 {
+		t.Errorf("AhKh wins %.3f, want about 0.5", w)
+	}
+}
+
+func TestCalculateSplit(t *testing.T) {
+	// The royal flush on the board is the best hand for both
+	result, err := Calculate(config(t, "AsKsQsJsTs", "2c3d", "4h5h"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Boards != 1 || result.Tie(0) != 1 || result.Equity(1) != 0.5 {
+		t.Errorf("Result = %+v, want one split board", result)
+	}
+}
+
+func TestCalculatePreflop(t *testing.T) {
+	if testing.Short() {
+		t.Skip("enumerates every preflop board")
+	}
+	result, err := Calculate(config(t, "", "AhAs", "KhKs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Exhaustive || result.Boards != 1712304 {
+		t.Fatalf("Boards = %d, exhaustive %v, want all 1712304", result.Boards, result.Exhaustive)
+	}
+	if eq := result.Equity(0); math.Abs(eq-0.8264) > 0.0001 {
+		t.Errorf("AhAs equity = %.4f, want 0.8264", eq)
+	}
+}
+
+func TestCalculateMonteCarlo(t *testing.T) {
+	cfg := config(t, "", "AhAs", "KhKs")
+	cfg.MaxBoards = 100000
+	result, err := Calculate(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Exhaustive || result.Boards != cfg.Trials {
+		t.Fatalf("Boards = %d, exhaustive %v, want %d samples", result.Boards, result.Exhaustive, cfg.Trials)
+	}
+	// Aces win about 82% against Kings
+	if eq := result.Equity(0); math.Abs(eq-0.82) > 0.02 {
+		t.Errorf("AhAs equity = %.3f, want about 0.82", eq)
+	}
+
+	// The same seed samples the same boards with any number of workers
+	cfg.Workers = 1
+	again, err := Calculate(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range result.Hands {
+		if result.Hands[i].Wins != again.Hands[i].Wins || result.Hands[i].Ties != again.Hands[i].Ties {
+			t.Errorf("hand %d differs between 4 and 1 workers: %+v and %+v", i, result.Hands[i], again.Hands[i])
+		}
+	}
+}
+
+func TestCalculateErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{"one hand", config(t, "", "AhKh")},
+		{"three hole cards", config(t, "", "AhKhQh", "2c2d")},
+		{"six board cards", config(t, "2c3c4c5c6c7c", "AhKh", "AsKs")},
+		{"no workers", Config{Hands: config(t, "", "AhKh", "AsKs").Hands}},
+	}
+	for _, tt := range tests {
+		if _, err := Calculate(tt.cfg); err == nil {
+			t.Errorf("%s: Calculate() did not fail", tt.name)
+		}
+	}
+
+	if _, err := Calculate(config(t, "Kh", "AhKh", "AsKs")); !errors.Is(err, ErrDuplicateCard) {
+		t.Errorf("Calculate() with Kh twice error = %v, want ErrDuplicateCard", err)
+	}
+}
@@ -0,0 +1,58 @@
+package pkr
+
+import (
+	"errors"
+
+	"github.com/litencatt/pkr/achievement"
+	"github.com/litencatt/pkr/advisor"
+	"github.com/litencatt/pkr/entity"
+)
+
+var (
+	// ErrInterrupted is returned when the player interrupts the game with Ctrl-C.
+	ErrInterrupted = errors.New("interrupted")
+	// ErrQuit is returned by a frontend when the player quits the game.
+	ErrQuit = errors.New("quit")
+)
+
+// State is the game state shown to the player.
+type State struct {
+	RulesName string
+	// Challenge is the challenge being played, if any.
+	Challenge      *entity.Challenge
+	MaxSelectCards int
+	Rounds         int
+	NewRound       bool
+	AnteAmount     int
+	BlindMulti     float64
+	Stats          entity.RoundStats
+	HandCards      []entity.Trump
+	DrawnCards     []entity.Trump
+	RemainCards    []entity.Trump
+	DeckRemaining  int
+	Actions        []string
+	HintsUsed      bool
+	Jokers         []entity.Joker
+	// Boss is the boss blind of the round, if any.
+	Boss *entity.BossBlind
+	// Hint is set when hints are on.
+	Hint *advisor.Advice
+	// Preview returns the stats the cards would score if played.
+	Preview func(cards []entity.Trump) (entity.PokerHandStats, error)
+}
+
+// Frontend renders the game and asks the player for input. Any method may
+// return ErrInterrupted or ErrQuit to end the game.
+type Frontend interface {
+	Start() error
+	Close() error
+	ShowState(state State) error
+	ShowMessage(message string) error
+	AskSelection(state State) ([]entity.Trump, error)
+	AskAction(state State, selected []entity.Trump, preview *entity.PokerHandStats) (string, error)
+	ShowResult(state State, result entity.PokerHandStats) error
+	ShowAchievement(a achievement.Achievement) error
+	ShowRoundClear(state State) error
+	ShowGameOver(state State) error
+	ShowRunWon(state State) error
+}
@@ -0,0 +1,348 @@
+package pkr
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/litencatt/pkr/achievement"
+	"github.com/litencatt/pkr/advisor"
+	"github.com/litencatt/pkr/bot"
+	"github.com/litencatt/pkr/entity"
+	"github.com/litencatt/pkr/profile"
+	"github.com/litencatt/pkr/service"
+)
+
+// Game runs the game flow against a PokerService and a Frontend.
+type Game struct {
+	Hints bool
+	// ProfileDir is where finished runs are recorded. Runs are not recorded
+	// when it is empty.
+	ProfileDir string
+	// RulesFile is the file the rules were read from, so that replays use
+	// the same rules. It is empty for the standard rules.
+	RulesFile string
+	service   service.PokerService
+	frontend  Frontend
+	// moves are the plays and discards of the run in the script format
+	moves []string
+	// roundStart is the stats at the start of the current round
+	roundStart entity.RoundStats
+}
+
+func NewGame(svc service.PokerService, frontend Frontend) *Game {
+	return &Game{
+		service:  svc,
+		frontend: frontend,
+	}
+}
+
+// Run plays until the game is over. Quitting ends the game without an error,
+// while interrupting returns ErrInterrupted.
+func (g *Game) Run() (err error) {
+	if err := g.frontend.Start(); err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := g.frontend.Close(); err == nil {
+			err = closeErr
+		}
+		if errors.Is(err, ErrQuit) {
+			err = nil
+		}
+	}()
+
+	for {
+		newRound := false
+		if g.service.IsStartRound() {
+			if err := g.service.StartRound(); err != nil {
+				return err
+			}
+			newRound = true
+		}
+
+		// Draw cards
+		drawn, err := g.service.DrawCard(g.service.GetNextDrawNum())
+		if err != nil {
+			return err
+		}
+		state := g.state()
+		if newRound {
+			g.roundStart = state.Stats
+		}
+		state.NewRound = newRound
+		state.DrawnCards = drawn
+		if g.Hints {
+			state.Hint = g.hint()
+		}
+		if err := g.frontend.ShowState(state); err != nil {
+			return err
+		}
+
+		// Select cards
+		var selected []entity.Trump
+		for {
+			selected, err = g.frontend.AskSelection(state)
+			if err != nil {
+				return err
+			}
+			if len(selected) <= state.MaxSelectCards {
+				break
+			}
+			if err := g.frontend.ShowMessage(fmt.Sprintf("Please select at most %d cards", state.MaxSelectCards)); err != nil {
+				return err
+			}
+		}
+
+		var selectCards []string
+		for _, card := range selected {
+			selectCards = append(selectCards, card.String())
+		}
+		var preview *entity.PokerHandStats
+		if len(selected) > 0 {
+			p, err := g.service.PreviewHand(selectCards)
+			if err != nil {
+				return err
+			}
+			preview = &p
+		}
+
+		// Play or Discard or Cancel
+		action, err := g.frontend.AskAction(state, selected, preview)
+		if err != nil {
+			return err
+		}
+		if !containsAction(state.Actions, action) {
+			return fmt.Errorf("action %q is not available", action)
+		}
+
+		if err := g.service.SelectCards(selectCards); err != nil {
+			return err
+		}
+		g.service.SetAction(action)
+		if action == "Play" || action == "Discard" {
+			g.moves = append(g.moves, move(action, state.HandCards, selected))
+		}
+		switch action {
+		case "Discard":
+			if err := g.service.DiscardHand(); err != nil {
+				return err
+			}
+			if g.service.IsRoundLost() {
+				return g.endRun()
+			}
+			continue
+		case "Cancel":
+			if err := g.service.CancelHand(); err != nil {
+				return err
+			}
+			continue
+		case "Hint":
+			// Keep the hand as it is and show hints from now on
+			g.Hints = true
+			g.service.SetAction("Cancel")
+			if err := g.service.CancelHand(); err != nil {
+				return err
+			}
+			continue
+		}
+
+		r, err := g.service.PlayHand()
+		if err != nil {
+			return err
+		}
+		if err := g.frontend.ShowResult(g.state(), r); err != nil {
+			return err
+		}
+		if err := g.achieve(achievement.Event{Type: achievement.HandPlayed, Hand: r}); err != nil {
+			return err
+		}
+
+		if g.service.IsRoundWin() {
+			stats := g.service.GetRoundStats()
+			if err := g.achieve(achievement.Event{
+				Type:         achievement.RoundCleared,
+				HandsUsed:    g.roundStart.Hands - stats.Hands,
+				DiscardsUsed: g.roundStart.Discards - stats.Discards,
+			}); err != nil {
+				return err
+			}
+			if err := g.frontend.ShowRoundClear(g.state()); err != nil {
+				return err
+			}
+			if err := g.service.NextRound(); err != nil {
+				return err
+			}
+			g.moves = append(g.moves, "next")
+			if g.service.IsRunWon() {
+				return g.endRun()
+			}
+			continue
+		}
+
+		if g.service.IsRoundLost() {
+			return g.endRun()
+		}
+	}
+}
+
+// endRun ends the run when a round is lost or the last ante is cleared.
+func (g *Game) endRun() error {
+	if err := g.achieve(achievement.Event{
+		Type:           achievement.RunEnded,
+		Won:            g.service.IsRunWon(),
+		CardsDiscarded: g.service.GetRunStats().CardsDiscarded,
+	}); err != nil {
+		return err
+	}
+	if err := g.record(); err != nil {
+		return err
+	}
+	if g.service.IsRunWon() {
+		return g.frontend.ShowRunWon(g.state())
+	}
+	return g.frontend.ShowGameOver(g.state())
+}
+
+func (g *Game) state() State {
+	actions := g.service.GetEnableActions()
+	if !g.Hints {
+		// Insert Hint before Cancel
+		actions = append(actions[:len(actions)-1], "Hint", actions[len(actions)-1])
+	}
+
+	return State{
+		RulesName:      g.service.GetRulesName(),
+		Challenge:      g.service.GetChallenge(),
+		MaxSelectCards: g.service.GetMaxSelectCards(),
+		Rounds:         g.service.GetRounds(),
+		AnteAmount:     g.service.GetCurrentAnteAmount(),
+		BlindMulti:     g.service.GetCurrentBlindMulti(),
+		Stats:          *g.service.GetRoundStats(),
+		HandCards:      append([]entity.Trump(nil), g.service.GetHandCards()...),
+		RemainCards:    g.service.GetRemainCards(),
+		DeckRemaining:  g.service.GetDeckRemaining(),
+		Actions:        actions,
+		HintsUsed:      g.service.IsHintsUsed(),
+		Jokers:         g.service.GetJokers(),
+		Boss:           g.service.GetBossBlind(),
+		Preview:        g.preview,
+	}
+}
+
+// hint returns advice for the current hand and flags the run as hinted.
+func (g *Game) hint() *advisor.Advice {
+	g.service.UseHints()
+
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano())) // #nosec G404 -- sampling does not need secure randomness
+	advice := advisor.Advise(bot.Observe(g.service), g.service.GetDeckCards(), advisor.DefaultSamples, rnd)
+	return &advice
+}
+
+// record adds the finished run to the player profile and the leaderboard.
+// Seeded runs are also saved as a replay script. Challenge runs are only
+// added to the profile.
+func (g *Game) record() error {
+	if g.ProfileDir == "" {
+		return nil
+	}
+
+	run := profile.Run{
+		Date:      time.Now(),
+		Deck:      g.service.GetDeckName(),
+		Won:       g.service.IsRunWon(),
+		Ante:      g.service.GetAnte(),
+		HintsUsed: g.service.IsHintsUsed(),
+		Stats:     g.service.GetRunStats(),
+	}
+	challenge := g.service.GetChallenge()
+	if challenge != nil {
+		run.Challenge = challenge.ID
+	}
+	if _, err := profile.Update(g.ProfileDir, func(p *profile.Profile) { p.Record(run) }); err != nil {
+		return err
+	}
+	// Challenges change the rules, so their runs are not comparable
+	if challenge != nil {
+		return nil
+	}
+
+	score := profile.Score{
+		Date:      run.Date,
+		Deck:      run.Deck,
+		DeckID:    g.service.GetDeckID(),
+		Stake:     g.service.GetStakeName(),
+		Rules:     g.service.GetRulesName(),
+		RulesFile: g.RulesFile,
+		Ante:      run.Ante,
+		Rounds:    g.service.GetRounds(),
+		BestHand:  run.Stats.BestHandScore,
+		Won:       run.Won,
+	}
+	for _, joker := range g.service.GetStartingJokers() {
+		score.Jokers = append(score.Jokers, joker.ID)
+	}
+	if seed, ok := g.service.GetSeed(); ok {
+		score.Seed = &seed
+		path, err := profile.SaveReplay(g.ProfileDir, score, g.moves)
+		if err != nil {
+			return err
+		}
+		score.Replay = path
+	}
+	return profile.AddScore(g.ProfileDir, score)
+}
+
+// achieve applies the event to the achievement progress in the player
+// profile and announces the achievements it earned.
+func (g *Game) achieve(e achievement.Event) error {
+	if g.ProfileDir == "" {
+		return nil
+	}
+
+	e.Ante = g.service.GetAnte()
+	var earned []achievement.Achievement
+	if _, err := profile.Update(g.ProfileDir, func(p *profile.Profile) {
+		earned = p.Achievements.Apply(e, time.Now())
+	}); err != nil {
+		return err
+	}
+
+	for _, a := range earned {
+		if err := g.frontend.ShowAchievement(a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// move formats a play or discard of the selected hand cards as a script line.
+func move(action string, hand, selected []entity.Trump) string {
+	fields := []string{strings.ToLower(action)}
+	for i, card := range hand {
+		if entity.Contains(selected, card) {
+			fields = append(fields, strconv.Itoa(i+1))
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+func (g *Game) preview(cards []entity.Trump) (entity.PokerHandStats, error) {
+	var selectCards []string
+	for _, card := range cards {
+		selectCards = append(selectCards, card.String())
+	}
+	return g.service.PreviewHand(selectCards)
+}
+
+func containsAction(actions []string, action string) bool {
+	for _, a := range actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,293 @@
+package pkr
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/litencatt/pkr/achievement"
+	"github.com/litencatt/pkr/entity"
+	"github.com/litencatt/pkr/profile"
+	"github.com/litencatt/pkr/service"
+)
+
+// recordFrontend plays the first cards of every hand and records what the
+// game shows.
+type recordFrontend struct {
+	out       strings.Builder
+	action    string
+	interrupt int
+	turns     int
+}
+
+func (f *recordFrontend) Start() error { return nil }
+func (f *recordFrontend) Close() error { return nil }
+
+func (f *recordFrontend) ShowState(state State) error {
+	if state.NewRound {
+		fmt.Fprintf(&f.out, "round %d\n", state.Rounds)
+	}
+	return nil
+}
+
+func (f *recordFrontend) ShowMessage(message string) error {
+	fmt.Fprintln(&f.out, message)
+	return nil
+}
+
+func (f *recordFrontend) AskSelection(state State) ([]entity.Trump, error) {
+	f.turns++
+	if f.interrupt > 0 && f.turns >= f.interrupt {
+		return nil, ErrInterrupted
+	}
+	return state.HandCards[:5], nil
+}
+
+func (f *recordFrontend) AskAction(state State, selected []entity.Trump, preview *entity.PokerHandStats) (string, error) {
+	if preview == nil {
+		return "", errors.New("preview should be set for selected cards")
+	}
+	return f.action, nil
+}
+
+func (f *recordFrontend) ShowResult(state State, result entity.PokerHandStats) error {
+	fmt.Fprintf(&f.out, "played %s\n", result.HandType)
+	return nil
+}
+
+func (f *recordFrontend) ShowAchievement(a achievement.Achievement) error {
+	fmt.Fprintf(&f.out, "achievement %s\n", a.ID)
+	return nil
+}
+
+func (f *recordFrontend) ShowRoundClear(state State) error {
+	fmt.Fprintln(&f.out, "round clear")
+	return nil
+}
+
+func (f *recordFrontend) ShowGameOver(state State) error {
+	fmt.Fprintln(&f.out, "game over")
+	return nil
+}
+
+func (f *recordFrontend) ShowRunWon(state State) error {
+	fmt.Fprintln(&f.out, "run won")
+	return nil
+}
+
+func TestGameRun(t *testing.T) {
+	seed := int64(1)
+	frontend := &recordFrontend{action: "Play"}
+	game := NewGame(service.NewPokerService(service.PokerServiceConfig{Seed: &seed}), frontend)
+
+	if err := game.Run(); err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+
+	out := frontend.out.String()
+	if !strings.HasPrefix(out, "round 1\n") {
+		t.Errorf("Output should start with round 1, got:\n%s", out)
+	}
+	if !strings.HasSuffix(out, "game over\n") {
+		t.Errorf("Output should end with game over, got:\n%s", out)
+	}
+	if strings.Count(out, "played ") < 4 {
+		t.Errorf("At least 4 hands should be played, got:\n%s", out)
+	}
+}
+
+func TestGameRunRecordsProfile(t *testing.T) {
+	seed := int64(1)
+	dir := t.TempDir()
+	frontend := &recordFrontend{action: "Play"}
+	game := NewGame(service.NewPokerService(service.PokerServiceConfig{Seed: &seed}), frontend)
+	game.ProfileDir = dir
+
+	for i := 0; i < 2; i++ {
+		if err := game.Run(); err != nil {
+			t.Fatalf("Run() returned error: %v", err)
+		}
+		game = NewGame(service.NewPokerService(service.PokerServiceConfig{Seed: &seed}), frontend)
+		game.ProfileDir = dir
+	}
+
+	p, err := profile.Load(dir)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if p.RunsPlayed != 2 {
+		t.Errorf("RunsPlayed = %d, want 2", p.RunsPlayed)
+	}
+	if p.FavoriteDeck != "Standard" {
+		t.Errorf("FavoriteDeck = %q, want Standard", p.FavoriteDeck)
+	}
+	if p.HighestHandScore == 0 || len(p.HandTypes) == 0 {
+		t.Errorf("Played hands should be recorded, got %+v", p)
+	}
+}
+
+func TestGameRunRecordsChallenge(t *testing.T) {
+	seed := int64(1)
+	dir := t.TempDir()
+	challenge := entity.Challenge{ID: "test", Name: "Test", Deck: entity.StandardDeck()}
+	game := NewGame(service.NewPokerService(service.PokerServiceConfig{Seed: &seed, Challenge: &challenge}), &recordFrontend{action: "Play"})
+	game.ProfileDir = dir
+
+	if err := game.Run(); err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+
+	p, err := profile.Load(dir)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if c := p.Challenges["test"]; c == nil || c.Attempts != 1 {
+		t.Errorf("Challenges[test] = %+v, want 1 attempt", c)
+	}
+	// Challenge runs are not on the leaderboard
+	scores, err := profile.LoadScores(dir)
+	if err != nil {
+		t.Fatalf("LoadScores() returned error: %v", err)
+	}
+	if len(scores) != 0 {
+		t.Errorf("len(scores) = %d, want 0", len(scores))
+	}
+}
+
+func TestGameRunRecordsScore(t *testing.T) {
+	seed := int64(7)
+	dir := t.TempDir()
+	frontend := &recordFrontend{action: "Play"}
+	game := NewGame(service.NewPokerService(service.PokerServiceConfig{Seed: &seed}), frontend)
+	game.ProfileDir = dir
+
+	if err := game.Run(); err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+
+	scores, err := profile.LoadScores(dir)
+	if err != nil {
+		t.Fatalf("LoadScores() returned error: %v", err)
+	}
+	if len(scores) != 1 {
+		t.Fatalf("LoadScores() returned %d scores, want 1", len(scores))
+	}
+	score := scores[0]
+	if score.Seed == nil || *score.Seed != seed || score.Deck != "Standard" || score.Stake != "White" {
+		t.Errorf("Score = %+v", score)
+	}
+
+	// The replay plays the same run again
+	replay, err := os.Open(score.Replay)
+	if err != nil {
+		t.Fatalf("Replay should be saved: %v", err)
+	}
+	defer replay.Close()
+	var out strings.Builder
+	if err := NewPokerScript(replay, &out, service.PokerServiceConfig{Seed: &seed}).Run(); err != nil {
+		t.Fatalf("Replaying returned error: %v\n%s", err, out.String())
+	}
+	if strings.Count(out.String(), "ROUND CLEAR") != strings.Count(frontend.out.String(), "round clear") {
+		t.Errorf("Replay should clear the same rounds, got:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "GAME OVER") {
+		t.Errorf("Replay should end with game over, got:\n%s", out.String())
+	}
+}
+
+func TestGameRunWon(t *testing.T) {
+	seed := int64(1)
+	dir := t.TempDir()
+	// Every hand clears the round
+	rules := entity.DefaultRules()
+	rules.AnteAmounts = []int{1, 1, 1, 1, 1, 1, 1, 1}
+	frontend := &recordFrontend{action: "Play"}
+	game := NewGame(service.NewPokerService(service.PokerServiceConfig{Seed: &seed, Rules: &rules}), frontend)
+	game.ProfileDir = dir
+
+	if err := game.Run(); err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+
+	out := frontend.out.String()
+	if !strings.HasSuffix(out, "run won\n") || strings.Contains(out, "game over") {
+		t.Errorf("Output should end with run won, got:\n%s", out)
+	}
+	if n := strings.Count(out, "round clear"); n != entity.WinAnte*len(rules.BlindMultis) {
+		t.Errorf("%d rounds cleared, want %d", n, entity.WinAnte*len(rules.BlindMultis))
+	}
+
+	p, err := profile.Load(dir)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if p.RunsPlayed != 1 || p.RunsWon != 1 {
+		t.Errorf("RunsPlayed, RunsWon = %d, %d, want 1, 1", p.RunsPlayed, p.RunsWon)
+	}
+	if p.BestAnte != entity.WinAnte {
+		t.Errorf("BestAnte = %d, want %d", p.BestAnte, entity.WinAnte)
+	}
+	if !p.Achievements.IsEarned("no_discards") {
+		t.Error("no_discards should be earned when the run is won")
+	}
+
+	scores, err := profile.LoadScores(dir)
+	if err != nil {
+		t.Fatalf("LoadScores() returned error: %v", err)
+	}
+	if len(scores) != 1 || scores[0].Ante != entity.WinAnte {
+		t.Errorf("scores = %+v, want one run at ante %d", scores, entity.WinAnte)
+	}
+}
+
+func TestGameRunAchievements(t *testing.T) {
+	seed := int64(42)
+	dir := t.TempDir()
+	frontend := &recordFrontend{action: "Play"}
+	game := NewGame(service.NewPokerService(service.PokerServiceConfig{Seed: &seed}), frontend)
+	game.ProfileDir = dir
+
+	if err := game.Run(); err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+
+	// Seed 42 clears the first round by playing the first five cards
+	out := frontend.out.String()
+	if strings.Count(out, "achievement first_blind\n") != 1 {
+		t.Errorf("first_blind should be announced once, got:\n%s", out)
+	}
+	if !strings.Contains(out, "achievement first_blind\nround clear\n") {
+		t.Errorf("first_blind should be announced before the round clear, got:\n%s", out)
+	}
+
+	p, err := profile.Load(dir)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if !p.Achievements.IsEarned("first_blind") {
+		t.Error("first_blind should be stored in the profile")
+	}
+	if p.Achievements.Counts["hands"] == 0 {
+		t.Error("Progress of played hands should be stored in the profile")
+	}
+}
+
+func TestGameRunInterrupted(t *testing.T) {
+	frontend := &recordFrontend{action: "Play", interrupt: 2}
+	game := NewGame(service.NewPokerService(service.NewPokerServiceConfig()), frontend)
+
+	if err := game.Run(); !errors.Is(err, ErrInterrupted) {
+		t.Errorf("Run() error = %v, want %v", err, ErrInterrupted)
+	}
+}
+
+func TestGameRunUnavailableAction(t *testing.T) {
+	frontend := &recordFrontend{action: "Fold"}
+	game := NewGame(service.NewPokerService(service.NewPokerServiceConfig()), frontend)
+
+	if err := game.Run(); err == nil {
+		t.Error("Run() with an unavailable action should return error")
+	}
+}
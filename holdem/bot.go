@@ -0,0 +1,126 @@
+package holdem
+
+import (
+	"math/rand"
+
+	"github.com/litencatt/pkr/entity"
+)
+
+// DefaultSamples is the number of deals a bot simulates per decision.
+const DefaultSamples = 300
+
+// Bot is a computer opponent. It estimates how often its hand wins against
+// the players left in the hand and bets when it is ahead, calls when the
+// pot odds are good enough and otherwise checks or folds.
+type Bot struct {
+	// Samples is the number of deals simulated per decision.
+	Samples int
+	// Bluff is the chance of betting a weak hand when nobody has bet.
+	Bluff float64
+
+	rnd *rand.Rand
+}
+
+// NewBot returns a bot whose decisions depend only on the seed and what it
+// sees.
+func NewBot(seed int64) *Bot {
+	return &Bot{
+		Samples: DefaultSamples,
+		Bluff:   0.05,
+		rnd:     rand.New(rand.NewSource(seed)), // #nosec G404 -- seeded for reproducible games
+	}
+}
+
+func (b *Bot) Act(v View) (Action, error) {
+	equity := Equity(v.Hole, v.Board, v.Opponents, b.Samples, b.rnd)
+	fair := 1 / float64(v.Opponents+1)
+	strong := fair + (1-fair)/2
+
+	switch {
+	case equity >= strong && v.CanRaise():
+		return Action{Type: Raise, Amount: b.raiseTo(v, equity)}, nil
+	case v.ToCall == 0:
+		if v.CanRaise() && (equity >= fair*1.3 || b.rnd.Float64() < b.Bluff) {
+			return Action{Type: Raise, Amount: b.raiseTo(v, equity)}, nil
+		}
+		return Action{Type: Check}, nil
+	case equity >= float64(v.ToCall)/float64(v.Pot+v.ToCall):
+		return Action{Type: Call}, nil
+	default:
+		return Action{Type: Fold}, nil
+	}
+}
+
+// raiseTo sizes a raise between half the pot and the full pot depending on
+// the equity, within the table limits.
+func (b *Bot) raiseTo(v View, equity float64) int {
+	amount := v.CurrentBet() + int(float64(v.Pot+v.ToCall)*(0.5+equity/2))
+	if amount < v.MinRaise {
+		amount = v.MinRaise
+	}
+	if amount > v.MaxRaise {
+		amount = v.MaxRaise
+	}
+	return amount
+}
+
+// Equity estimates the share of the pot that two hole cards win against
+// the given number of opponents holding random cards, by dealing the
+// opponents and the rest of the board samples times.
+func Equity(hole, board []entity.Trump, opponents, samples int, rnd *rand.Rand) float64 {
+	if opponents == 0 {
+		return 1
+	}
+	if samples <= 0 {
+		samples = DefaultSamples
+	}
+
+	seen := map[entity.Trump]bool{}
+	for _, card := range append(append([]entity.Trump(nil), hole...), board...) {
+		seen[card] = true
+	}
+	var unseen []entity.CardCode
+	for _, card := range entity.NewDeck() {
+		if !seen[card] {
+			unseen = append(unseen, entity.EncodeCard(card))
+		}
+	}
+
+	var mine, theirs [7]entity.CardCode
+	for i, card := range hole {
+		mine[i] = entity.EncodeCard(card)
+	}
+	for i, card := range board {
+		mine[len(hole)+i] = entity.EncodeCard(card)
+	}
+	missing := 5 - len(board)
+
+	won := 0.0
+	for n := 0; n < samples; n++ {
+		// Partial shuffle of just the cards this deal needs
+		need := missing + 2*opponents
+		for i := 0; i < need; i++ {
+			j := i + rnd.Intn(len(unseen)-i)
+			unseen[i], unseen[j] = unseen[j], unseen[i]
+		}
+		runout := unseen[:missing]
+		copy(mine[len(hole)+len(board):], runout)
+		best := entity.RankBestCodes(mine[:])
+
+		ties, beaten := 0, false
+		copy(theirs[2:], mine[2:])
+		for o := 0; o < opponents && !beaten; o++ {
+			theirs[0], theirs[1] = unseen[missing+2*o], unseen[missing+2*o+1]
+			switch rank := entity.RankBestCodes(theirs[:]); {
+			case rank > best:
+				beaten = true
+			case rank == best:
+				ties++
+			}
+		}
+		if !beaten {
+			won += 1 / float64(ties+1)
+		}
+	}
+	return won / float64(samples)
+}
@@ -0,0 +1,448 @@
+// Package holdem plays no-limit Texas Hold'em hands between agents.
+package holdem
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/litencatt/pkr/betting"
+	"github.com/litencatt/pkr/entity"
+)
+
+// MaxPlayers is the largest table size.
+const MaxPlayers = 6
+
+// ErrIllegalAction is returned when an agent chooses an action that the
+// betting rules do not allow.
+var ErrIllegalAction = betting.ErrIllegalAction
+
+type ActionType string
+
+const (
+	Fold  ActionType = "fold"
+	Check ActionType = "check"
+	Call  ActionType = "call"
+	// Raise is a bet, or a raise when someone has already bet.
+	Raise ActionType = "raise"
+)
+
+// Action is a player's decision. Amount is only used by Raise and is the
+// total the player bets on this street ("raise to").
+type Action struct {
+	Type   ActionType
+	Amount int
+}
+
+type Street string
+
+const (
+	Preflop Street = "Preflop"
+	Flop    Street = "Flop"
+	Turn    Street = "Turn"
+	River   Street = "River"
+)
+
+// Agent decides what a player does when it is their turn.
+type Agent interface {
+	Act(v View) (Action, error)
+}
+
+// Observer is told what happens at the table, so that a frontend can show
+// it. HandStarted is called once the blinds are posted.
+type Observer interface {
+	HandStarted(t *Table)
+	Acted(t *Table, p *Player, a Action)
+	BoardDealt(t *Table)
+	HandEnded(t *Table, r Result)
+}
+
+// Player is a seat at the table. The table keeps Stack, Folded, Bet and
+// Total up to date with the betting of the current hand.
+type Player struct {
+	Name  string
+	Stack int
+	Agent Agent
+	// Hole is the player's two cards, or nil when they sit out the hand.
+	Hole   []entity.Trump
+	Folded bool
+	// Bet is what the player has put in on the current street and Total
+	// what they have put in during the whole hand.
+	Bet   int
+	Total int
+}
+
+// InHand reports whether the player can still win the pot.
+func (p *Player) InHand() bool {
+	return p.Hole != nil && !p.Folded
+}
+
+// AllIn reports whether the player has put all their chips in the pot.
+func (p *Player) AllIn() bool {
+	return p.InHand() && p.Stack == 0
+}
+
+// Seat is what everyone can see about a player.
+type Seat struct {
+	Name   string
+	Stack  int
+	Bet    int
+	Folded bool
+	AllIn  bool
+	Out    bool
+}
+
+// View is what a player can see when it is their turn.
+type View struct {
+	Seat   int
+	Seats  []Seat
+	Dealer int
+	Hole   []entity.Trump
+	Board  []entity.Trump
+	Street Street
+	Pot    int
+	Stack  int
+	Bet    int
+	// ToCall is what the player has to add to stay in the hand.
+	ToCall int
+	// MinRaise and MaxRaise bound the Amount of a Raise. A raise below
+	// MinRaise is only allowed when it puts the player all in.
+	MinRaise int
+	MaxRaise int
+	BigBlind int
+	// Opponents is the number of other players still in the hand.
+	Opponents int
+}
+
+// CanRaise reports whether the player may raise.
+func (v View) CanRaise() bool {
+	return v.MaxRaise > v.Bet+v.ToCall
+}
+
+// CurrentBet is the bet to match on this street.
+func (v View) CurrentBet() int {
+	return v.Bet + v.ToCall
+}
+
+// Pot is a share of the chips and the players who can win it.
+type Pot struct {
+	Amount  int
+	Winners []*Player
+}
+
+// Result is the outcome of a hand.
+type Result struct {
+	Pots []Pot
+	// Won is what each winner took from the pots.
+	Won map[*Player]int
+	// Hands holds the best hand of every player at the showdown, and is
+	// empty when everyone else folded.
+	Hands map[*Player]entity.BestHand
+}
+
+// Config holds the table stakes.
+type Config struct {
+	SmallBlind int
+	BigBlind   int
+	Seed       int64
+}
+
+// Table deals hands to its players and runs the betting.
+type Table struct {
+	Players    []*Player
+	Dealer     int
+	SmallBlind int
+	BigBlind   int
+	Board      []entity.Trump
+	Street     Street
+	// HandNumber counts the hands played, starting at 1.
+	HandNumber int
+	Observer   Observer
+
+	deck entity.Deck
+	rnd  *rand.Rand
+	hand *betting.Hand
+}
+
+// NewTable seats the players with the button on the first one. The table
+// starts with an observer that ignores everything.
+func NewTable(config Config, players []*Player) (*Table, error) {
+	if len(players) < 2 || len(players) > MaxPlayers {
+		return nil, fmt.Errorf("a table needs 2 to %d players, got %d", MaxPlayers, len(players))
+	}
+	if config.SmallBlind <= 0 || config.BigBlind < config.SmallBlind {
+		return nil, fmt.Errorf("invalid blinds %d/%d", config.SmallBlind, config.BigBlind)
+	}
+	for _, p := range players {
+		if p.Stack <= 0 {
+			return nil, fmt.Errorf("%s has no chips", p.Name)
+		}
+		if p.Agent == nil {
+			return nil, fmt.Errorf("%s has no agent", p.Name)
+		}
+	}
+
+	return &Table{
+		Players:    players,
+		SmallBlind: config.SmallBlind,
+		BigBlind:   config.BigBlind,
+		Observer:   nopObserver{},
+		rnd:        rand.New(rand.NewSource(config.Seed)), // #nosec G404 -- seeded for reproducible deals
+	}, nil
+}
+
+// Seated returns the players who still have chips.
+func (t *Table) Seated() []*Player {
+	var players []*Player
+	for _, p := range t.Players {
+		if p.Stack > 0 {
+			players = append(players, p)
+		}
+	}
+	return players
+}
+
+// Pot is the sum of all bets in the current hand.
+func (t *Table) Pot() int {
+	if t.hand == nil {
+		return 0
+	}
+	return t.hand.Pot()
+}
+
+// PlayHand deals one hand, runs the betting and pays the winners, then
+// moves the button.
+func (t *Table) PlayHand() (Result, error) {
+	if len(t.Seated()) < 2 {
+		return Result{}, errors.New("not enough players with chips")
+	}
+	t.HandNumber++
+	t.deal()
+	first := t.next(t.postBlinds())
+	t.Observer.HandStarted(t)
+
+	for _, street := range []Street{Preflop, Flop, Turn, River} {
+		if street != Preflop {
+			t.dealBoard(street)
+			first = t.next(t.Dealer)
+		}
+		if err := t.bettingRound(first); err != nil {
+			return Result{}, err
+		}
+		if t.inHand() == 1 {
+			break
+		}
+	}
+
+	result := t.showdown()
+	t.Observer.HandEnded(t, result)
+	t.moveButton()
+	return result, nil
+}
+
+func (t *Table) deal() {
+	t.deck = entity.NewDeck()
+	t.deck.ShuffleWith(t.rnd)
+	t.Board = nil
+	t.Street = Preflop
+	stacks := make([]int, len(t.Players))
+	for i, p := range t.Players {
+		p.Hole = nil
+		if p.Stack > 0 {
+			p.Hole = append([]entity.Trump(nil), t.deck.Draw(2)...)
+		}
+		stacks[i] = p.Stack
+	}
+	if t.Players[t.Dealer].Hole == nil {
+		t.Dealer = t.next(t.Dealer)
+	}
+	t.hand = betting.NewHand(stacks, t.Dealer, t.BigBlind)
+	t.sync()
+}
+
+// sync copies the chips of the betting hand to the players.
+func (t *Table) sync() {
+	for i, p := range t.Players {
+		p.Stack = t.hand.Stack(i)
+		p.Folded = t.hand.Folded(i)
+		p.Bet = t.hand.Bet(i)
+		p.Total = t.hand.Total(i)
+	}
+}
+
+// postBlinds returns the big blind seat. Heads-up the button posts the
+// small blind.
+func (t *Table) postBlinds() int {
+	sb := t.next(t.Dealer)
+	if t.inHand() == 2 {
+		sb = t.Dealer
+	}
+	bb := t.next(sb)
+	t.hand.Post(sb, t.SmallBlind)
+	t.hand.Post(bb, t.BigBlind)
+	t.sync()
+	return bb
+}
+
+func (t *Table) dealBoard(street Street) {
+	t.Street = street
+	t.deck.Draw(1) // burn
+	n := 1
+	if street == Flop {
+		n = 3
+	}
+	t.Board = append(t.Board, t.deck.Draw(n)...)
+	t.Observer.BoardDealt(t)
+}
+
+// next returns the next seat after i that was dealt in.
+func (t *Table) next(i int) int {
+	for j := 1; j <= len(t.Players); j++ {
+		seat := (i + j) % len(t.Players)
+		if t.Players[seat].Hole != nil {
+			return seat
+		}
+	}
+	return i
+}
+
+func (t *Table) moveButton() {
+	for j := 1; j <= len(t.Players); j++ {
+		seat := (t.Dealer + j) % len(t.Players)
+		if t.Players[seat].Stack > 0 {
+			t.Dealer = seat
+			return
+		}
+	}
+}
+
+func (t *Table) inHand() int {
+	return t.hand.Active()
+}
+
+// bettingRound asks the players for actions, starting at seat first, until
+// everyone still able to act has matched the current bet.
+func (t *Table) bettingRound(first int) error {
+	for seat := first; !t.hand.Done(); seat = (seat + 1) % len(t.Players) {
+		if !t.hand.NeedsAction(seat) {
+			continue
+		}
+
+		p := t.Players[seat]
+		a, err := p.Agent.Act(t.view(seat))
+		if err != nil {
+			return err
+		}
+		if err := t.apply(seat, a); err != nil {
+			return fmt.Errorf("%s: %w", p.Name, err)
+		}
+		t.sync()
+		t.Observer.Acted(t, p, a)
+	}
+
+	t.hand.EndStreet()
+	t.sync()
+	return nil
+}
+
+func (t *Table) apply(seat int, a Action) error {
+	switch a.Type {
+	case Fold:
+		return t.hand.Fold(seat)
+	case Check:
+		return t.hand.Check(seat)
+	case Call:
+		return t.hand.Call(seat)
+	case Raise:
+		return t.hand.RaiseTo(seat, a.Amount)
+	default:
+		return fmt.Errorf("%w: unknown action %q", ErrIllegalAction, a.Type)
+	}
+}
+
+func (t *Table) view(seat int) View {
+	p := t.Players[seat]
+	v := View{
+		Seat:     seat,
+		Dealer:   t.Dealer,
+		Hole:     p.Hole,
+		Board:    t.Board,
+		Street:   t.Street,
+		Pot:      t.Pot(),
+		Stack:    p.Stack,
+		Bet:      p.Bet,
+		ToCall:   t.hand.ToCall(seat),
+		BigBlind: t.BigBlind,
+	}
+	if t.hand.CanRaise(seat) {
+		v.MinRaise = t.hand.MinRaise(seat)
+		v.MaxRaise = t.hand.MaxRaise(seat)
+	}
+	for i, other := range t.Players {
+		v.Seats = append(v.Seats, Seat{
+			Name:   other.Name,
+			Stack:  other.Stack,
+			Bet:    other.Bet,
+			Folded: other.Folded,
+			AllIn:  other.AllIn(),
+			Out:    other.Hole == nil,
+		})
+		if i != seat && other.InHand() {
+			v.Opponents++
+		}
+	}
+	return v
+}
+
+// showdown pays the main pot and each side pot to the best hands among
+// the players who can win it.
+func (t *Table) showdown() Result {
+	result := Result{
+		Won:   map[*Player]int{},
+		Hands: map[*Player]entity.BestHand{},
+	}
+	if t.inHand() > 1 {
+		for _, p := range t.Players {
+			if p.InHand() {
+				hand, _ := entity.BestFive(append(append([]entity.Trump(nil), p.Hole...), t.Board...))
+				result.Hands[p] = hand
+			}
+		}
+	}
+
+	for _, pot := range t.hand.Pots() {
+		var winners []int
+		for _, seat := range pot.Eligible {
+			if len(winners) == 0 {
+				winners = []int{seat}
+				continue
+			}
+			best := result.Hands[t.Players[winners[0]]].Strength
+			switch c := result.Hands[t.Players[seat]].Strength.Compare(best); {
+			case c > 0:
+				winners = []int{seat}
+			case c == 0:
+				winners = append(winners, seat)
+			}
+		}
+
+		// The winners are eligible, so Award cannot fail
+		payouts, _ := t.hand.Award(pot, winners)
+		won := Pot{Amount: pot.Amount}
+		for _, seat := range winners {
+			p := t.Players[seat]
+			won.Winners = append(won.Winners, p)
+			result.Won[p] += payouts[seat]
+		}
+		result.Pots = append(result.Pots, won)
+	}
+	t.sync()
+	return result
+}
+
+type nopObserver struct{}
+
+func (nopObserver) HandStarted(*Table)            {}
+func (nopObserver) Acted(*Table, *Player, Action) {}
+func (nopObserver) BoardDealt(*Table)             {}
+func (nopObserver) HandEnded(*Table, Result)      {}
@@ -0,0 +1,327 @@
+package holdem
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/litencatt/pkr/betting"
+	"github.com/litencatt/pkr/entity"
+)
+
+// scripted plays the given actions in order, then checks or calls.
+type scripted struct {
+	actions []Action
+	views   []View
+}
+
+func (s *scripted) Act(v View) (Action, error) {
+	s.views = append(s.views, v)
+	if len(s.actions) > 0 {
+		a := s.actions[0]
+		s.actions = s.actions[1:]
+		return a, nil
+	}
+	if v.ToCall > 0 {
+		return Action{Type: Call}, nil
+	}
+	return Action{Type: Check}, nil
+}
+
+func newTable(t *testing.T, stacks ...int) (*Table, []*scripted) {
+	t.Helper()
+	var players []*Player
+	var agents []*scripted
+	for i, stack := range stacks {
+		agent := &scripted{}
+		agents = append(agents, agent)
+		players = append(players, &Player{Name: string(rune('A' + i)), Stack: stack, Agent: agent})
+	}
+	table, err := NewTable(Config{SmallBlind: 5, BigBlind: 10, Seed: 1}, players)
+	if err != nil {
+		t.Fatalf("NewTable() returned error: %v", err)
+	}
+	return table, agents
+}
+
+func cards(t *testing.T, s string) []entity.Trump {
+	t.Helper()
+	hand, err := entity.ParseCards(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hand
+}
+
+func chips(players []*Player) int {
+	total := 0
+	for _, p := range players {
+		total += p.Stack
+	}
+	return total
+}
+
+func TestNewTable(t *testing.T) {
+	agent := &scripted{}
+	tests := []struct {
+		name    string
+		config  Config
+		players []*Player
+	}{
+		{"one player", Config{SmallBlind: 5, BigBlind: 10}, []*Player{{Name: "A", Stack: 100, Agent: agent}}},
+		{"no chips", Config{SmallBlind: 5, BigBlind: 10}, []*Player{{Name: "A", Stack: 100, Agent: agent}, {Name: "B", Agent: agent}}},
+		{"no agent", Config{SmallBlind: 5, BigBlind: 10}, []*Player{{Name: "A", Stack: 100, Agent: agent}, {Name: "B", Stack: 100}}},
+		{"bad blinds", Config{SmallBlind: 10, BigBlind: 5}, []*Player{{Name: "A", Stack: 100, Agent: agent}, {Name: "B", Stack: 100, Agent: agent}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewTable(tt.config, tt.players); err == nil {
+				t.Error("NewTable() should return error")
+			}
+		})
+	}
+}
+
+func TestPlayHandHeadsUpFold(t *testing.T) {
+	table, agents := newTable(t, 1000, 1000)
+	agents[0].actions = []Action{{Type: Fold}}
+
+	result, err := table.PlayHand()
+	if err != nil {
+		t.Fatalf("PlayHand() returned error: %v", err)
+	}
+
+	// Heads-up the button posts the small blind and acts first
+	if v := agents[0].views[0]; v.Bet != 5 || v.ToCall != 5 || v.MinRaise != 20 || v.MaxRaise != 1000 {
+		t.Errorf("button view = %+v, want bet 5, to call 5, raise 20 to 1000", v)
+	}
+	if got := table.Players[0].Stack; got != 995 {
+		t.Errorf("button stack = %d, want 995", got)
+	}
+	if got := table.Players[1].Stack; got != 1005 {
+		t.Errorf("big blind stack = %d, want 1005", got)
+	}
+	// The uncalled half of the big blind is returned before the pot is won
+	if got := result.Won[table.Players[1]]; got != 10 {
+		t.Errorf("Won = %d, want 10", got)
+	}
+	if len(result.Hands) != 0 {
+		t.Error("a hand won by a fold should have no showdown")
+	}
+	if table.Dealer != 1 {
+		t.Errorf("Dealer = %d, want 1", table.Dealer)
+	}
+}
+
+func TestPlayHandOrder(t *testing.T) {
+	table, agents := newTable(t, 1000, 1000, 1000)
+	var order []string
+	for i, agent := range agents {
+		name := table.Players[i].Name
+		table.Players[i].Agent = agentFunc(func(v View) (Action, error) {
+			order = append(order, name+":"+string(v.Street))
+			return agent.Act(v)
+		})
+	}
+
+	result, err := table.PlayHand()
+	if err != nil {
+		t.Fatalf("PlayHand() returned error: %v", err)
+	}
+
+	// The big blind gets the option preflop, and the small blind acts first
+	// after the flop
+	want := "A:Preflop B:Preflop C:Preflop B:Flop C:Flop A:Flop B:Turn C:Turn A:Turn B:River C:River A:River"
+	if got := strings.Join(order, " "); got != want {
+		t.Errorf("order = %s, want %s", got, want)
+	}
+	if len(result.Hands) != 3 || len(table.Board) != 5 {
+		t.Errorf("showdown had %d hands and %d board cards, want 3 and 5", len(result.Hands), len(table.Board))
+	}
+	if got := chips(table.Players); got != 3000 {
+		t.Errorf("chips = %d, want 3000", got)
+	}
+}
+
+type agentFunc func(v View) (Action, error)
+
+func (f agentFunc) Act(v View) (Action, error) { return f(v) }
+
+func TestPlayHandRaise(t *testing.T) {
+	table, agents := newTable(t, 1000, 1000, 1000)
+	agents[0].actions = []Action{{Type: Raise, Amount: 30}}
+	agents[1].actions = []Action{{Type: Raise, Amount: 80}}
+	agents[2].actions = []Action{{Type: Fold}}
+
+	if _, err := table.PlayHand(); err != nil {
+		t.Fatalf("PlayHand() returned error: %v", err)
+	}
+
+	// A's raise of 20 sets the minimum, B re-raises by 50
+	if v := agents[1].views[0]; v.ToCall != 25 || v.MinRaise != 50 {
+		t.Errorf("small blind view = %+v, want to call 25 and raise to 50", v)
+	}
+	if v := agents[0].views[1]; v.ToCall != 50 || v.MinRaise != 130 {
+		t.Errorf("button view = %+v, want to call 50 and raise to 130", v)
+	}
+	if got := chips(table.Players); got != 3000 {
+		t.Errorf("chips = %d, want 3000", got)
+	}
+}
+
+func TestPlayHandIllegal(t *testing.T) {
+	tests := []struct {
+		name   string
+		action Action
+	}{
+		{"check facing a bet", Action{Type: Check}},
+		{"raise below minimum", Action{Type: Raise, Amount: 15}},
+		{"raise above stack", Action{Type: Raise, Amount: 2000}},
+		{"unknown", Action{Type: "bluff"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, agents := newTable(t, 1000, 1000)
+			agents[0].actions = []Action{tt.action}
+			if _, err := table.PlayHand(); !errors.Is(err, ErrIllegalAction) {
+				t.Errorf("PlayHand() error = %v, want ErrIllegalAction", err)
+			}
+		})
+	}
+}
+
+func TestPlayHandAllIn(t *testing.T) {
+	table, agents := newTable(t, 100, 1000)
+	agents[0].actions = []Action{{Type: Raise, Amount: 100}}
+
+	result, err := table.PlayHand()
+	if err != nil {
+		t.Fatalf("PlayHand() returned error: %v", err)
+	}
+
+	// Nobody bets once the short stack is all in, and the board runs out
+	if len(agents[1].views) != 1 {
+		t.Errorf("big blind acted %d times, want 1", len(agents[1].views))
+	}
+	if len(table.Board) != 5 || len(result.Hands) != 2 {
+		t.Errorf("board = %d cards, hands = %d, want 5 and 2", len(table.Board), len(result.Hands))
+	}
+	if got := chips(table.Players); got != 1100 {
+		t.Errorf("chips = %d, want 1100", got)
+	}
+}
+
+func TestShowdownSidePots(t *testing.T) {
+	table, _ := newTable(t, 50, 300, 300, 300)
+	table.Dealer = 3
+	table.Board = cards(t, "2c 7d 9h Js 4c")
+	for i, hole := range []string{"Ac Ad", "Kc Kd", "3s 5h", "Jc Jd"} {
+		table.Players[i].Hole = cards(t, hole)
+	}
+	table.hand = betting.NewHand([]int{50, 300, 300, 300}, table.Dealer, table.BigBlind)
+	table.hand.Post(0, 50)  // best hand, all in
+	table.hand.Post(1, 200) // second best
+	table.hand.Post(2, 200) // worst
+	table.hand.Post(3, 120) // folds a set
+	if err := table.hand.Fold(3); err != nil {
+		t.Fatal(err)
+	}
+
+	result := table.showdown()
+
+	if len(result.Pots) != 2 {
+		t.Fatalf("pots = %+v, want a main pot and a side pot", result.Pots)
+	}
+	if got := result.Pots[0].Amount; got != 200 {
+		t.Errorf("main pot = %d, want 200", got)
+	}
+	if got := result.Pots[1].Amount; got != 370 {
+		t.Errorf("side pot = %d, want 370", got)
+	}
+	if got := result.Won[table.Players[0]]; got != 200 {
+		t.Errorf("A won %d, want 200", got)
+	}
+	if got := result.Won[table.Players[1]]; got != 370 {
+		t.Errorf("B won %d, want 370", got)
+	}
+	if got := chips(table.Players); got != 950 {
+		t.Errorf("chips = %d, want 950", got)
+	}
+}
+
+func TestShowdownSplit(t *testing.T) {
+	table, _ := newTable(t, 100, 100, 100)
+	table.Dealer = 2
+	table.Board = cards(t, "Ts Js Qs Ks As")
+	for i, hole := range []string{"2c 3d", "4c 5d", "6c 7d"} {
+		table.Players[i].Hole = cards(t, hole)
+	}
+	table.hand = betting.NewHand([]int{100, 100, 100}, table.Dealer, table.BigBlind)
+	for seat := range table.Players {
+		table.hand.Post(seat, 11)
+	}
+	if err := table.hand.Fold(2); err != nil {
+		t.Fatal(err)
+	}
+
+	result := table.showdown()
+
+	// The board plays, and the odd chip goes left of the button
+	if got := table.Players[0].Stack; got != 106 {
+		t.Errorf("A stack = %d, want 106", got)
+	}
+	if got := table.Players[1].Stack; got != 105 {
+		t.Errorf("B stack = %d, want 105", got)
+	}
+	if len(result.Pots) != 1 || len(result.Pots[0].Winners) != 2 {
+		t.Errorf("pots = %+v, want one pot with two winners", result.Pots)
+	}
+}
+
+func TestBotsConserveChips(t *testing.T) {
+	var players []*Player
+	for i := 0; i < MaxPlayers; i++ {
+		bot := NewBot(int64(i))
+		bot.Samples = 50
+		players = append(players, &Player{Name: string(rune('A' + i)), Stack: 500, Agent: bot})
+	}
+	table, err := NewTable(Config{SmallBlind: 5, BigBlind: 10, Seed: 7}, players)
+	if err != nil {
+		t.Fatalf("NewTable() returned error: %v", err)
+	}
+
+	for i := 0; i < 200 && len(table.Seated()) > 1; i++ {
+		if _, err := table.PlayHand(); err != nil {
+			t.Fatalf("hand %d: PlayHand() returned error: %v", table.HandNumber, err)
+		}
+		if got := chips(players); got != 3000 {
+			t.Fatalf("hand %d: chips = %d, want 3000", table.HandNumber, got)
+		}
+	}
+}
+
+func TestEquity(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1)) // #nosec G404 -- tests do not need secure randomness
+	tests := []struct {
+		name      string
+		hole      string
+		board     string
+		opponents int
+		min, max  float64
+	}{
+		{"aces heads-up", "Ah As", "", 1, 0.82, 0.88},
+		{"seven deuce heads-up", "7h 2c", "", 1, 0.31, 0.38},
+		{"aces five-way", "Ah As", "", 4, 0.52, 0.60},
+		{"nut flush on the river", "Ah Kh", "2h 7h 9h Jc 3d", 1, 0.99, 1},
+		{"no opponents", "7h 2c", "", 0, 1, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Equity(cards(t, tt.hole), cards(t, tt.board), tt.opponents, 5000, rnd)
+			if got < tt.min || got > tt.max {
+				t.Errorf("Equity() = %.3f, want between %.2f and %.2f", got, tt.min, tt.max)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package pkr
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/litencatt/pkr/entity"
+	"github.com/litencatt/pkr/holdem"
+)
+
+// HoldemConfig sets up a Texas Hold'em table against bots.
+type HoldemConfig struct {
+	// Players is the table size including you.
+	Players    int
+	Stack      int
+	SmallBlind int
+	BigBlind   int
+	Seed       int64
+}
+
+// HoldemCLI plays Texas Hold'em against bots with survey prompts. It is the
+// agent of your seat and the observer of the table.
+type HoldemCLI struct {
+	table *holdem.Table
+	you   *holdem.Player
+	out   io.Writer
+	sleep time.Duration
+}
+
+func NewHoldemCLI(config HoldemConfig) (*HoldemCLI, error) {
+	if config.Stack <= 0 {
+		return nil, fmt.Errorf("the starting stack must be positive, got %d", config.Stack)
+	}
+	cli := &HoldemCLI{
+		out:   os.Stdout,
+		sleep: time.Second,
+	}
+	cli.you = &holdem.Player{Name: "You", Stack: config.Stack, Agent: cli}
+	players := []*holdem.Player{cli.you}
+	for i := 1; i < config.Players; i++ {
+		players = append(players, &holdem.Player{
+			Name:  fmt.Sprintf("Bot %d", i),
+			Stack: config.Stack,
+			Agent: holdem.NewBot(config.Seed + int64(i)),
+		})
+	}
+
+	table, err := holdem.NewTable(holdem.Config{
+		SmallBlind: config.SmallBlind,
+		BigBlind:   config.BigBlind,
+		Seed:       config.Seed,
+	}, players)
+	if err != nil {
+		return nil, err
+	}
+	table.Observer = cli
+	cli.table = table
+	return cli, nil
+}
+
+// Run plays hands until you are out of chips, win every chip or quit.
+func (cli *HoldemCLI) Run() error {
+	for {
+		if cli.you.Stack == 0 {
+			fmt.Fprintln(cli.out, "💀 You are out of chips. Better luck next time!")
+			return nil
+		}
+		if len(cli.table.Seated()) == 1 {
+			fmt.Fprintf(cli.out, "🏆 You won every chip at the table in %d hands!\n", cli.table.HandNumber)
+			return nil
+		}
+
+		if cli.table.HandNumber > 0 {
+			var next string
+			prompt := &survey.Select{
+				Message: "Ready for the next hand?",
+				Options: []string{"Next Hand →", "Quit"},
+			}
+			if err := ask(prompt, &next); err != nil {
+				return err
+			}
+			if next == "Quit" {
+				return nil
+			}
+		}
+
+		if _, err := cli.table.PlayHand(); err != nil {
+			return err
+		}
+	}
+}
+
+func (cli *HoldemCLI) HandStarted(t *holdem.Table) {
+	ClearTerminal()
+	printBox(cli.out, fmt.Sprintf("🃏 HAND %d", t.HandNumber), fmt.Sprintf("Blinds: %d/%d", t.SmallBlind, t.BigBlind))
+	cli.printSeats(t)
+	fmt.Fprintf(cli.out, "🂠 Your cards: %s\n", cardLabels(cli.you.Hole))
+	fmt.Fprintln(cli.out)
+}
+
+func (cli *HoldemCLI) printSeats(t *holdem.Table) {
+	for i, p := range t.Players {
+		if p.Hole == nil {
+			continue
+		}
+		button := "  "
+		if i == t.Dealer {
+			button = "Ⓓ "
+		}
+		status := ""
+		switch {
+		case p.Folded:
+			status = "  (folded)"
+		case p.AllIn():
+			status = "  (all in)"
+		case p.Bet > 0:
+			status = fmt.Sprintf("  bet %d", p.Bet)
+		}
+		fmt.Fprintf(cli.out, "%s%-6s 💰 %5d%s\n", button, p.Name, p.Stack, status)
+	}
+}
+
+func (cli *HoldemCLI) Acted(t *holdem.Table, p *holdem.Player, a holdem.Action) {
+	if p == cli.you {
+		return
+	}
+	fmt.Fprintf(cli.out, "  %s %s\n", p.Name, describeAction(p, a))
+	time.Sleep(cli.sleep / 2)
+}
+
+func describeAction(p *holdem.Player, a holdem.Action) string {
+	s := string(a.Type) + "s"
+	switch a.Type {
+	case holdem.Call:
+		s = fmt.Sprintf("calls %d", p.Bet)
+	case holdem.Raise:
+		s = fmt.Sprintf("raises to %d", a.Amount)
+	}
+	if p.AllIn() {
+		s += " (all in)"
+	}
+	return s
+}
+
+func (cli *HoldemCLI) BoardDealt(t *holdem.Table) {
+	fmt.Fprintln(cli.out)
+	fmt.Fprintf(cli.out, "────────── %s ──────────\n", t.Street)
+	fmt.Fprintf(cli.out, "  Board: %s   Pot: %d\n", cardLabels(t.Board), t.Pot())
+	time.Sleep(cli.sleep / 2)
+}
+
+func (cli *HoldemCLI) HandEnded(t *holdem.Table, r holdem.Result) {
+	fmt.Fprintln(cli.out)
+	if len(r.Hands) > 0 {
+		fmt.Fprintln(cli.out, "────────── Showdown ──────────")
+		for _, p := range t.Players {
+			if hand, ok := r.Hands[p]; ok {
+				fmt.Fprintf(cli.out, "  %-6s %s  %s\n", p.Name, cardLabels(p.Hole), hand.Strength)
+			}
+		}
+		fmt.Fprintln(cli.out)
+	}
+	for _, p := range t.Players {
+		if won := r.Won[p]; won > 0 {
+			fmt.Fprintf(cli.out, "🏆 %s won %d\n", p.Name, won)
+		}
+	}
+	fmt.Fprintln(cli.out)
+}
+
+// Act asks you for an action, offering only the legal ones.
+func (cli *HoldemCLI) Act(v holdem.View) (holdem.Action, error) {
+	fmt.Fprintln(cli.out)
+	cli.printSeats(cli.table)
+	fmt.Fprintf(cli.out, "🂠 Your cards: %s   Board: %s   Pot: %d\n", cardLabels(v.Hole), cardLabels(v.Board), v.Pot)
+
+	actions := map[string]holdem.Action{}
+	var options []string
+	add := func(option string, a holdem.Action) {
+		options = append(options, option)
+		actions[option] = a
+	}
+	if v.ToCall == 0 {
+		add("Check", holdem.Action{Type: holdem.Check})
+	} else {
+		add(fmt.Sprintf("Call %d", v.ToCall), holdem.Action{Type: holdem.Call})
+	}
+	if v.CanRaise() {
+		verb := "Raise"
+		if v.CurrentBet() == 0 {
+			verb = "Bet"
+		}
+		if v.MinRaise < v.MaxRaise {
+			add(fmt.Sprintf("%s (%d to %d)", verb, v.MinRaise, v.MaxRaise), holdem.Action{Type: holdem.Raise})
+		}
+		add(fmt.Sprintf("All in (%d)", v.MaxRaise), holdem.Action{Type: holdem.Raise, Amount: v.MaxRaise})
+	}
+	add("Fold", holdem.Action{Type: holdem.Fold})
+
+	var option string
+	prompt := &survey.Select{
+		Message: "Your action:",
+		Options: options,
+	}
+	if err := ask(prompt, &option); err != nil {
+		return holdem.Action{}, err
+	}
+	a := actions[option]
+	if a.Type != holdem.Raise || a.Amount != 0 {
+		return a, nil
+	}
+
+	var amount string
+	input := &survey.Input{
+		Message: fmt.Sprintf("Raise to (%d-%d):", v.MinRaise, v.MaxRaise),
+		Default: strconv.Itoa(v.MinRaise),
+	}
+	validate := survey.WithValidator(func(ans interface{}) error {
+		n, err := strconv.Atoi(strings.TrimSpace(ans.(string)))
+		if err != nil || n < v.MinRaise || n > v.MaxRaise {
+			return fmt.Errorf("enter a number from %d to %d", v.MinRaise, v.MaxRaise)
+		}
+		return nil
+	})
+	if err := ask(input, &amount, validate); err != nil {
+		return holdem.Action{}, err
+	}
+	a.Amount, _ = strconv.Atoi(strings.TrimSpace(amount))
+	return a, nil
+}
+
+func cardLabels(cards []entity.Trump) string {
+	if len(cards) == 0 {
+		return "-"
+	}
+	var labels []string
+	for _, card := range cards {
+		labels = append(labels, cardLabel(card))
+	}
+	return strings.Join(labels, " ")
+}
@@ -0,0 +1,168 @@
+package pack
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/litencatt/pkr/entity"
+)
+
+//go:embed challenges.yaml
+var builtinChallenges []byte
+
+// builtin is the pack of the built-in challenges.
+var builtin = func() *Pack {
+	p, err := Parse(builtinChallenges)
+	if err != nil {
+		panic(err)
+	}
+	p.builtin = true
+	return p
+}()
+
+// Dir returns the directory packs are loaded from, ~/.pkr/packs.
+func Dir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".pkr", "packs"), nil
+}
+
+// Catalog is the content of all valid packs.
+type Catalog struct {
+	Packs []*Pack
+}
+
+// LoadDir loads every .yaml, .yml and .json file in dir. Invalid packs are
+// left out of the catalog and reported in the returned errors. A missing
+// directory gives an empty catalog.
+func LoadDir(dir string) (*Catalog, []error) {
+	catalog := &Catalog{}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return catalog, nil
+	}
+	if err != nil {
+		return catalog, []error{err}
+	}
+
+	var errs []error
+	names := make(map[string]string)
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml" && ext != ".json") {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		p, err := loadFile(path)
+		if err == nil {
+			if other, ok := names[p.Name]; ok {
+				err = fmt.Errorf("pack %q is already defined in %s", p.Name, other)
+			}
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			continue
+		}
+		names[p.Name] = path
+		catalog.Packs = append(catalog.Packs, p)
+	}
+	return catalog, errs
+}
+
+func loadFile(path string) (*Pack, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- packs are read from the packs directory
+	if err != nil {
+		return nil, err
+	}
+	p, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	p.Path = path
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// FindDeck returns the built-in deck with the given name, or the pack deck
+// with the given namespaced ID.
+func (c *Catalog) FindDeck(name string) (entity.StartingDeck, error) {
+	ns, id, ok := strings.Cut(name, ":")
+	if !ok {
+		return entity.FindStartingDeck(name)
+	}
+	for _, p := range c.Packs {
+		if p.Name != ns {
+			continue
+		}
+		if d := p.findDeck(id); d != nil {
+			return p.deck(*d)
+		}
+	}
+	return entity.StartingDeck{}, fmt.Errorf("unknown deck %q", name)
+}
+
+// BossBlinds returns the boss blinds of all packs.
+func (c *Catalog) BossBlinds() []entity.BossBlind {
+	var bosses []entity.BossBlind
+	for _, p := range c.Packs {
+		for _, b := range p.BossBlinds {
+			boss, _ := p.bossBlind(b)
+			bosses = append(bosses, boss)
+		}
+	}
+	return bosses
+}
+
+// FindJoker returns the joker with the given namespaced ID.
+func (c *Catalog) FindJoker(name string) (entity.Joker, error) {
+	ns, id, _ := strings.Cut(name, ":")
+	for _, p := range c.Packs {
+		if p.Name == ns {
+			for _, j := range p.Jokers {
+				if j.ID == id {
+					return p.joker(j)
+				}
+			}
+		}
+	}
+	return entity.Joker{}, fmt.Errorf("unknown joker %q", name)
+}
+
+// Challenges returns the built-in challenges followed by the challenges of
+// all packs.
+func (c *Catalog) Challenges() []entity.Challenge {
+	var challenges []entity.Challenge
+	for _, p := range append([]*Pack{builtin}, c.Packs...) {
+		for _, ch := range p.Challenges {
+			challenge, err := p.challenge(ch)
+			if err != nil {
+				// Packs are validated when loaded
+				continue
+			}
+			challenges = append(challenges, challenge)
+		}
+	}
+	return challenges
+}
+
+// FindChallenge returns the challenge with the given ID.
+func (c *Catalog) FindChallenge(id string) (entity.Challenge, error) {
+	var ids []string
+	for _, challenge := range c.Challenges() {
+		if strings.EqualFold(challenge.ID, id) {
+			return challenge, nil
+		}
+		ids = append(ids, challenge.ID)
+	}
+	return entity.Challenge{}, fmt.Errorf("unknown challenge %q (available: %s)", id, strings.Join(ids, ", "))
+}
@@ -0,0 +1,437 @@
+package pack
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/litencatt/pkr/achievement"
+	"github.com/litencatt/pkr/entity"
+	"github.com/litencatt/pkr/rules"
+	"github.com/litencatt/pkr/scripting"
+	"gopkg.in/yaml.v3"
+)
+
+// Pack is a content pack file. Its name is the namespace of everything it
+// defines, so the joker "fan" of the pack "mine" is "mine:fan".
+type Pack struct {
+	Name        string      `yaml:"name"`
+	Description string      `yaml:"description"`
+	Jokers      []Joker     `yaml:"jokers"`
+	Decks       []Deck      `yaml:"decks"`
+	BossBlinds  []BossBlind `yaml:"boss_blinds"`
+	Challenges  []Challenge `yaml:"challenges"`
+	// Path is the file the pack was loaded from.
+	Path string `yaml:"-"`
+	// builtin packs do not namespace their IDs.
+	builtin bool
+}
+
+// Joker is a joker definition.
+type Joker struct {
+	ID          string   `yaml:"id"`
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description"`
+	Effects     []Effect `yaml:"effects"`
+	// Script is a Starlark file with hooks, relative to the pack file.
+	Script string `yaml:"script"`
+	// UnlockedBy is the ID of the achievement that unlocks the joker. The
+	// joker can always be played when it is empty.
+	UnlockedBy string `yaml:"unlocked_by"`
+}
+
+// Effect is an effect of a joker, for example
+//
+//	if: {hand: Flush}
+//	mult: 20
+//
+// adds 20 mult to hands containing a Flush, and
+//
+//	for_each: {suit: Hearts}
+//	x_mult: 1.5
+//
+// multiplies the mult by 1.5 for every scoring Heart.
+type Effect struct {
+	If      *HandCondition `yaml:"if"`
+	ForEach *CardCondition `yaml:"for_each"`
+	Chips   int            `yaml:"chips"`
+	Mult    int            `yaml:"mult"`
+	XMult   float64        `yaml:"x_mult"`
+}
+
+// HandCondition matches hands containing a hand type.
+type HandCondition struct {
+	Hand string `yaml:"hand"`
+}
+
+// CardCondition matches cards by suit and rank.
+type CardCondition struct {
+	Suit string `yaml:"suit"`
+	Rank string `yaml:"rank"`
+}
+
+// Deck is a starting deck definition. The cards are the standard 52 cards
+// limited to the given suits and ranks.
+type Deck struct {
+	ID          string   `yaml:"id"`
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description"`
+	Hands       int      `yaml:"hands"`
+	Discards    int      `yaml:"discards"`
+	Suits       []string `yaml:"suits"`
+	Ranks       []string `yaml:"ranks"`
+	// Jokers are IDs of jokers in the same pack.
+	Jokers []string `yaml:"jokers"`
+}
+
+// BossBlind is a boss blind definition.
+type BossBlind struct {
+	ID          string         `yaml:"id"`
+	Name        string         `yaml:"name"`
+	Description string         `yaml:"description"`
+	ScoreMulti  float64        `yaml:"score_multi"`
+	Hands       int            `yaml:"hands"`
+	Discards    int            `yaml:"discards"`
+	Debuff      *CardCondition `yaml:"debuff"`
+	// Script is a Starlark file with hooks, relative to the pack file.
+	Script string `yaml:"script"`
+}
+
+// Challenge is a run with fixed starting conditions and rule changes.
+type Challenge struct {
+	ID          string `yaml:"id"`
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	// Deck is a built-in deck name or the ID of a deck in the same pack.
+	Deck string `yaml:"deck"`
+	// Jokers are IDs of jokers in the same pack.
+	Jokers []string    `yaml:"jokers"`
+	Rules  *rules.File `yaml:"rules"`
+	// OnlyHands are the only hand types that score, if set.
+	OnlyHands []string `yaml:"only_hands"`
+}
+
+var idPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Parse reads a pack from YAML or JSON. Unknown fields are rejected.
+func Parse(data []byte) (*Pack, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	var p Pack
+	if err := dec.Decode(&p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// ID returns the namespaced ID of a definition in the pack.
+func (p *Pack) ID(id string) string {
+	if p.builtin {
+		return id
+	}
+	return p.Name + ":" + id
+}
+
+// Validate returns all problems of the pack joined into one error.
+func (p *Pack) Validate() error {
+	var errs []error
+	if !idPattern.MatchString(p.Name) {
+		errs = append(errs, fmt.Errorf("name %q must be lowercase letters, digits, - and _", p.Name))
+	}
+
+	ids := make(map[string]bool)
+	checkID := func(kind string, i int, id string) {
+		switch {
+		case !idPattern.MatchString(id):
+			errs = append(errs, fmt.Errorf("%s[%d]: id %q must be lowercase letters, digits, - and _", kind, i, id))
+		case ids[kind+":"+id]:
+			errs = append(errs, fmt.Errorf("%s[%d]: duplicate id %q", kind, i, id))
+		}
+		ids[kind+":"+id] = true
+	}
+
+	for i, j := range p.Jokers {
+		checkID("jokers", i, j.ID)
+		if _, err := p.joker(j); err != nil {
+			errs = append(errs, fmt.Errorf("jokers[%d] (%s): %w", i, j.ID, err))
+		}
+	}
+	for i, d := range p.Decks {
+		checkID("decks", i, d.ID)
+		if _, err := p.deck(d); err != nil {
+			errs = append(errs, fmt.Errorf("decks[%d] (%s): %w", i, d.ID, err))
+		}
+	}
+	for i, b := range p.BossBlinds {
+		checkID("boss_blinds", i, b.ID)
+		if _, err := p.bossBlind(b); err != nil {
+			errs = append(errs, fmt.Errorf("boss_blinds[%d] (%s): %w", i, b.ID, err))
+		}
+	}
+	for i, c := range p.Challenges {
+		checkID("challenges", i, c.ID)
+		if _, err := p.challenge(c); err != nil {
+			errs = append(errs, fmt.Errorf("challenges[%d] (%s): %w", i, c.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p *Pack) joker(j Joker) (entity.Joker, error) {
+	joker := entity.Joker{ID: p.ID(j.ID), Name: j.Name, Description: j.Description, UnlockedBy: j.UnlockedBy}
+	if j.Name == "" {
+		return joker, errors.New("name must not be empty")
+	}
+	if _, ok := achievement.Find(j.UnlockedBy); j.UnlockedBy != "" && !ok {
+		return joker, fmt.Errorf("unlocked_by: unknown achievement %q", j.UnlockedBy)
+	}
+	if len(j.Effects) == 0 && j.Script == "" {
+		return joker, errors.New("at least one effect or a script is needed")
+	}
+	if j.Script != "" {
+		script, err := p.script(j.Script)
+		if err != nil {
+			return joker, err
+		}
+		joker.Hooks = script
+	}
+
+	for i, e := range j.Effects {
+		effect := entity.Effect{Chips: e.Chips, Mult: e.Mult, XMult: e.XMult}
+		if e.Chips == 0 && e.Mult == 0 && e.XMult == 0 {
+			return joker, fmt.Errorf("effects[%d]: set chips, mult or x_mult", i)
+		}
+		if e.XMult < 0 {
+			return joker, fmt.Errorf("effects[%d]: x_mult must not be negative", i)
+		}
+		if e.If != nil {
+			handType, err := rules.ParseHandType(e.If.Hand)
+			if err != nil {
+				return joker, fmt.Errorf("effects[%d].if: %w", i, err)
+			}
+			effect.If = &entity.HandCondition{Contains: handType}
+		}
+		if e.ForEach != nil {
+			cond, err := cardCondition(*e.ForEach)
+			if err != nil {
+				return joker, fmt.Errorf("effects[%d].for_each: %w", i, err)
+			}
+			effect.ForEach = cond
+		}
+		joker.Effects = append(joker.Effects, effect)
+	}
+	return joker, nil
+}
+
+func (p *Pack) deck(d Deck) (entity.StartingDeck, error) {
+	deck := entity.StartingDeck{
+		ID:            p.ID(d.ID),
+		Name:          d.Name,
+		Description:   d.Description,
+		ExtraHands:    d.Hands,
+		ExtraDiscards: d.Discards,
+	}
+	if d.Name == "" {
+		return deck, errors.New("name must not be empty")
+	}
+	if d.Description == "" {
+		deck.Description = d.Name
+	}
+
+	suits := make(map[entity.Suit]bool)
+	for _, s := range d.Suits {
+		suit, err := parseSuit(s)
+		if err != nil {
+			return deck, fmt.Errorf("suits: %w", err)
+		}
+		suits[suit] = true
+	}
+	ranks := make(map[entity.Rank]bool)
+	for _, r := range d.Ranks {
+		rank, err := parseRank(r)
+		if err != nil {
+			return deck, fmt.Errorf("ranks: %w", err)
+		}
+		ranks[rank] = true
+	}
+	if len(suits) > 0 || len(ranks) > 0 {
+		for _, card := range entity.NewDeck() {
+			if (len(suits) == 0 || suits[card.Suit]) && (len(ranks) == 0 || ranks[card.Rank]) {
+				deck.Cards = append(deck.Cards, card)
+			}
+		}
+		// A round deals 8 cards and draws more after plays
+		if len(deck.Cards) < 10 {
+			return deck, fmt.Errorf("the deck needs at least 10 cards, got %d", len(deck.Cards))
+		}
+	}
+
+	for _, id := range d.Jokers {
+		joker, err := p.findJoker(id)
+		if err != nil {
+			return deck, err
+		}
+		deck.Jokers = append(deck.Jokers, joker)
+	}
+	return deck, nil
+}
+
+func (p *Pack) bossBlind(b BossBlind) (entity.BossBlind, error) {
+	boss := entity.BossBlind{
+		ID:            p.ID(b.ID),
+		Name:          b.Name,
+		Description:   b.Description,
+		ScoreMulti:    b.ScoreMulti,
+		ExtraHands:    b.Hands,
+		ExtraDiscards: b.Discards,
+	}
+	if b.Name == "" {
+		return boss, errors.New("name must not be empty")
+	}
+	if b.ScoreMulti < 0 {
+		return boss, errors.New("score_multi must not be negative")
+	}
+	if b.Debuff != nil {
+		cond, err := cardCondition(*b.Debuff)
+		if err != nil {
+			return boss, fmt.Errorf("debuff: %w", err)
+		}
+		boss.Debuff = cond
+	}
+	if b.Script != "" {
+		script, err := p.script(b.Script)
+		if err != nil {
+			return boss, err
+		}
+		boss.Hooks = script
+	}
+	return boss, nil
+}
+
+// script loads a script file relative to the pack file. Every call loads
+// the script again, so that every run starts with a fresh script state.
+func (p *Pack) script(name string) (*scripting.Script, error) {
+	path := name
+	if p.Path != "" && !filepath.IsAbs(name) {
+		path = filepath.Join(filepath.Dir(p.Path), name)
+	}
+	script, err := scripting.LoadFile(path, scripting.DefaultLimits())
+	if err != nil {
+		return nil, fmt.Errorf("script: %w", err)
+	}
+	return script, nil
+}
+
+func (p *Pack) challenge(c Challenge) (entity.Challenge, error) {
+	challenge := entity.Challenge{ID: p.ID(c.ID), Name: c.Name, Description: c.Description}
+	if c.Name == "" {
+		return challenge, errors.New("name must not be empty")
+	}
+
+	var err error
+	switch d := p.findDeck(c.Deck); {
+	case c.Deck == "":
+		challenge.Deck = entity.StandardDeck()
+	case d != nil:
+		challenge.Deck, err = p.deck(*d)
+	default:
+		challenge.Deck, err = entity.FindStartingDeck(c.Deck)
+		if err != nil {
+			err = fmt.Errorf("deck: %q is neither a built-in deck nor a deck of the pack", c.Deck)
+		}
+	}
+	if err != nil {
+		return challenge, err
+	}
+
+	for _, id := range c.Jokers {
+		joker, err := p.findJoker(id)
+		if err != nil {
+			return challenge, err
+		}
+		challenge.Jokers = append(challenge.Jokers, joker)
+	}
+	if c.Rules != nil {
+		r, err := c.Rules.Rules()
+		if err != nil {
+			return challenge, fmt.Errorf("rules: %w", err)
+		}
+		if c.Rules.Name == "" {
+			r.Name = c.Name
+		}
+		challenge.Rules = &r
+	}
+	for _, name := range c.OnlyHands {
+		handType, err := rules.ParseHandType(name)
+		if err != nil {
+			return challenge, fmt.Errorf("only_hands: %w", err)
+		}
+		challenge.OnlyHands = append(challenge.OnlyHands, handType)
+	}
+	return challenge, nil
+}
+
+func (p *Pack) findJoker(id string) (entity.Joker, error) {
+	for _, j := range p.Jokers {
+		if j.ID == id {
+			return p.joker(j)
+		}
+	}
+	return entity.Joker{}, fmt.Errorf("jokers: unknown joker %q in pack %s", id, p.Name)
+}
+
+func (p *Pack) findDeck(id string) *Deck {
+	for i := range p.Decks {
+		if p.Decks[i].ID == id {
+			return &p.Decks[i]
+		}
+	}
+	return nil
+}
+
+func cardCondition(c CardCondition) (*entity.CardCondition, error) {
+	if c.Suit == "" && c.Rank == "" {
+		return nil, errors.New("set suit or rank")
+	}
+	cond := &entity.CardCondition{}
+	if c.Suit != "" {
+		suit, err := parseSuit(c.Suit)
+		if err != nil {
+			return nil, err
+		}
+		cond.Suit = suit
+	}
+	if c.Rank != "" {
+		rank, err := parseRank(c.Rank)
+		if err != nil {
+			return nil, err
+		}
+		cond.Rank = rank
+	}
+	return cond, nil
+}
+
+func parseSuit(s string) (entity.Suit, error) {
+	for _, suit := range []entity.Suit{entity.Clubs, entity.Diamonds, entity.Hearts, entity.Spades} {
+		if strings.EqualFold(string(suit), s) {
+			return suit, nil
+		}
+	}
+	return "", fmt.Errorf("unknown suit %q (available: Clubs, Diamonds, Hearts, Spades)", s)
+}
+
+func parseRank(s string) (entity.Rank, error) {
+	if s == "10" {
+		return entity.Ten, nil
+	}
+	for _, card := range entity.NewDeck()[:13] {
+		if strings.EqualFold(string(card.Rank), s) {
+			return card.Rank, nil
+		}
+	}
+	return "", fmt.Errorf("unknown rank %q (available: 2-10, J, Q, K, A)", s)
+}
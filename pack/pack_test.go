@@ -0,0 +1,290 @@
+package pack
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/litencatt/pkr/entity"
+)
+
+const testPack = `
+name: hearts
+description: Love is in the air
+jokers:
+  - id: lover
+    name: Lover
+    description: x1.5 mult for each scored Heart
+    effects:
+      - for_each: {suit: Hearts}
+        x_mult: 1.5
+  - id: flusher
+    name: Flusher
+    unlocked_by: ante_3
+    effects:
+      - if: {hand: flush}
+        mult: 20
+decks:
+  - id: red-hearts
+    name: Red Hearts
+    suits: [Hearts, Diamonds]
+    ranks: ["10", J, Q, K, A, "2", "3"]
+    discards: 1
+    jokers: [lover]
+boss_blinds:
+  - id: the-club
+    name: The Club
+    description: Clubs score no chips
+    score_multi: 2
+    debuff: {suit: Clubs}
+challenges:
+  - id: flush-only
+    name: Flush Only
+    deck: red-hearts
+    jokers: [flusher]
+    only_hands: [Flush]
+    rules:
+      discards: 0
+`
+
+func TestParse(t *testing.T) {
+	p, err := Parse([]byte(testPack))
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+	if err := p.Validate(); err != nil {
+		t.Fatalf("Validate() returned error: %v", err)
+	}
+
+	catalog := &Catalog{Packs: []*Pack{p}}
+	deck, err := catalog.FindDeck("hearts:red-hearts")
+	if err != nil {
+		t.Fatalf("FindDeck() returned error: %v", err)
+	}
+	if deck.ID != "hearts:red-hearts" || deck.Name != "Red Hearts" {
+		t.Errorf("ID, Name = %q, %q, want hearts:red-hearts, Red Hearts", deck.ID, deck.Name)
+	}
+	if len(deck.Cards) != 14 || deck.ExtraDiscards != 1 {
+		t.Errorf("len(Cards), ExtraDiscards = %d, %d, want 14, 1", len(deck.Cards), deck.ExtraDiscards)
+	}
+	if len(deck.Jokers) != 1 || deck.Jokers[0].ID != "hearts:lover" {
+		t.Errorf("Jokers = %v, want hearts:lover", deck.Jokers)
+	}
+
+	// Built-in decks are found by name
+	if _, err := catalog.FindDeck("red"); err != nil {
+		t.Errorf("FindDeck(red) returned error: %v", err)
+	}
+	if _, err := catalog.FindDeck("other:red-hearts"); err == nil {
+		t.Error("FindDeck() of an unknown pack should return error")
+	}
+
+	joker, err := catalog.FindJoker("hearts:flusher")
+	if err != nil {
+		t.Fatalf("FindJoker() returned error: %v", err)
+	}
+	if joker.Effects[0].If.Contains != entity.Flush {
+		t.Errorf("If = %v, want Flush", joker.Effects[0].If)
+	}
+	if joker.UnlockedBy != "ante_3" {
+		t.Errorf("UnlockedBy = %q, want ante_3", joker.UnlockedBy)
+	}
+
+	challenge, err := catalog.FindChallenge("hearts:flush-only")
+	if err != nil {
+		t.Fatalf("FindChallenge() returned error: %v", err)
+	}
+	if challenge.Deck.ID != "hearts:red-hearts" || challenge.Deck.Name != "Red Hearts" || len(challenge.Jokers) != 1 || challenge.Rules.Discards != 0 {
+		t.Errorf("Challenge = %+v, want the red-hearts deck, one joker and no discards", challenge)
+	}
+	// Rules without a name are named after the challenge
+	if challenge.Rules.Name != "Flush Only" || !challenge.Scores(entity.Flush) || challenge.Scores(entity.OnePair) {
+		t.Errorf("Rules.Name, OnlyHands = %q, %v", challenge.Rules.Name, challenge.OnlyHands)
+	}
+
+	bosses := catalog.BossBlinds()
+	if len(bosses) != 1 || bosses[0].ID != "hearts:the-club" || !bosses[0].IsDebuffed(entity.Trump{Suit: entity.Clubs, Rank: entity.Ace}) {
+		t.Errorf("BossBlinds() = %v, want hearts:the-club debuffing Clubs", bosses)
+	}
+}
+
+func TestParseUnknownField(t *testing.T) {
+	_, err := Parse([]byte("name: typo\njokerz: []\n"))
+	if err == nil || !strings.Contains(err.Error(), "jokerz") {
+		t.Errorf("Parse() error = %v, want an error naming jokerz", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	p, err := Parse([]byte(`
+name: Bad Name
+jokers:
+  - id: a
+    name: A
+    effects:
+      - if: {hand: Five of a Kind}
+        mult: 1
+  - id: a
+    name: Again
+    effects:
+      - for_each: {suit: Stars}
+        chips: 1
+  - id: b
+    name: Nothing
+    effects:
+      - if: {hand: Flush}
+  - id: c
+    name: Secret
+    unlocked_by: win_everything
+    effects:
+      - mult: 1
+decks:
+  - id: tiny
+    name: Tiny
+    ranks: [A, K]
+    jokers: [missing]
+  - id: nameless
+boss_blinds:
+  - id: boss
+    name: ""
+challenges:
+  - id: c
+    name: C
+    deck: Purple
+  - id: d
+    name: D
+    rules:
+      hands: 0
+`))
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+
+	err = p.Validate()
+	if err == nil {
+		t.Fatal("Validate() should return error")
+	}
+	for _, want := range []string{
+		`name "Bad Name"`,
+		`unknown hand type "Five of a Kind"`,
+		`jokers[1]: duplicate id "a"`,
+		`unknown suit "Stars"`,
+		`effects[0]: set chips, mult or x_mult`,
+		`unlocked_by: unknown achievement "win_everything"`,
+		`at least 10 cards, got 8`,
+		`decks[1] (nameless): name must not be empty`,
+		`boss_blinds[0] (boss): name must not be empty`,
+		`deck: "Purple" is neither`,
+		`hands must be at least 1`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() error should contain %q, got:\n%v", want, err)
+		}
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"hearts.yaml": testPack,
+		"copy.yml":    testPack,
+		"broken.json": `{"name": "broken", "jokers": [{"id": "x"}]}`,
+		"notes.txt":   "not a pack",
+		"spades.json": `{"name": "spades", "jokers": [{"id": "x", "name": "X", "effects": [{"chips": 5}]}]}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	catalog, errs := LoadDir(dir)
+	var names []string
+	for _, p := range catalog.Packs {
+		names = append(names, p.Name)
+	}
+	// copy.yml is loaded first and hearts.yaml is a duplicate of it
+	if strings.Join(names, ",") != "hearts,spades" {
+		t.Errorf("Packs = %v, want hearts, spades", names)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("LoadDir() returned %d errors, want 2: %v", len(errs), errs)
+	}
+	if !strings.Contains(errs[1].Error(), "already defined") {
+		t.Errorf("errs[1] = %v, want a duplicate pack error", errs[1])
+	}
+
+	catalog, errs = LoadDir(filepath.Join(dir, "missing"))
+	if len(catalog.Packs) != 0 || len(errs) != 0 {
+		t.Errorf("LoadDir() of a missing directory = %v, %v, want an empty catalog", catalog.Packs, errs)
+	}
+}
+
+func TestPackScript(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"scripted.yaml": `
+name: scripted
+jokers:
+  - id: fan
+    name: Fan
+    script: scripts/fan.star
+boss_blinds:
+  - id: broken
+    name: Broken
+    script: scripts/missing.star
+`,
+		"scripts/fan.star": "def on_hand_scored(ctx, hand):\n    ctx.add_mult(3)\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, errs := LoadDir(dir)
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "boss_blinds[0] (broken): script:") {
+		t.Fatalf("LoadDir() errors = %v, want the missing script of the boss blind", errs)
+	}
+
+	// Scripts are found next to the pack file
+	p, _ := Parse([]byte(files["scripted.yaml"]))
+	p.Path = filepath.Join(dir, "scripted.yaml")
+	joker, err := p.joker(p.Jokers[0])
+	if err != nil {
+		t.Fatalf("joker() returned error: %v", err)
+	}
+	stats := entity.PokerHandStats{Chip: 10, Mult: 1}
+	if err := joker.Hooks.OnHandScored(&stats); err != nil || stats.Mult != 4 {
+		t.Errorf("OnHandScored() = %v with Mult %d, want Mult 4", err, stats.Mult)
+	}
+}
+
+func TestBuiltinChallenges(t *testing.T) {
+	if err := builtin.Validate(); err != nil {
+		t.Fatalf("the built-in challenges are invalid: %v", err)
+	}
+
+	catalog := &Catalog{}
+	challenges := catalog.Challenges()
+	if len(challenges) != len(builtin.Challenges) {
+		t.Fatalf("Challenges() returned %d challenges, want %d", len(challenges), len(builtin.Challenges))
+	}
+	// Built-in challenges are not namespaced
+	c, err := catalog.FindChallenge("Royal-Court")
+	if err != nil {
+		t.Fatalf("FindChallenge() returned error: %v", err)
+	}
+	if len(c.Deck.Cards) != 20 || len(c.Jokers) != 1 {
+		t.Errorf("Royal Court has %d cards and %d jokers, want 20 and 1", len(c.Deck.Cards), len(c.Jokers))
+	}
+
+	if _, err := catalog.FindChallenge("missing"); err == nil || !strings.Contains(err.Error(), "mindful") {
+		t.Errorf("FindChallenge() error = %v, want the available challenges", err)
+	}
+}
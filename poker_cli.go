@@ -2,6 +2,7 @@ package pkr
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"runtime"
@@ -9,6 +10,8 @@ import (
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/AlecAivazis/survey/v2/terminal"
+	"github.com/litencatt/pkr/achievement"
+	"github.com/litencatt/pkr/entity"
 	"github.com/litencatt/pkr/service"
 )
 
@@ -16,16 +19,22 @@ const Name string = "pkr"
 
 var Version = "dev"
 
+// PokerCLI is the prompt frontend built on survey prompts.
 type PokerCLI struct {
-	DebugMode bool
-	service   service.PokerService
+	DebugMode  bool
+	Hints      bool
+	ProfileDir string
+	RulesFile  string
+	service    service.PokerService
+	out        io.Writer
+	sleep      time.Duration
 }
 
-func NewPokerCLI() *PokerCLI {
+func NewPokerCLI(config service.PokerServiceConfig) *PokerCLI {
 	return &PokerCLI{
-		service: service.NewPokerService(service.PokerServiceConfig{
-			DebugMode: true,
-		}),
+		service: service.NewPokerService(config),
+		out:     os.Stdout,
+		sleep:   time.Second,
 	}
 }
 
@@ -54,15 +63,15 @@ func ClearTerminal() {
 	}
 }
 
-func printBox(title, content string) {
-	fmt.Println("┌─────────────────────────────────────────┐")
-	fmt.Printf("│ %-39s │\n", title)
-	fmt.Println("├─────────────────────────────────────────┤")
-	fmt.Printf("│ %-39s │\n", content)
-	fmt.Println("└─────────────────────────────────────────┘")
+func printBox(w io.Writer, title, content string) {
+	fmt.Fprintln(w, "┌─────────────────────────────────────────┐")
+	fmt.Fprintf(w, "│ %-39s │\n", title)
+	fmt.Fprintln(w, "├─────────────────────────────────────────┤")
+	fmt.Fprintf(w, "│ %-39s │\n", content)
+	fmt.Fprintln(w, "└─────────────────────────────────────────┘")
 }
 
-func printProgressBar(current, target int) {
+func printProgressBar(w io.Writer, current, target int) {
 	barWidth := 30
 	var progress float64
 	if target > 0 {
@@ -83,176 +92,219 @@ func printProgressBar(current, target int) {
 	}
 
 	percentage := int(progress * 100)
-	fmt.Printf("📊 Score Progress: [%s] %d%% (%d/%d)\n", bar, percentage, current, target)
+	fmt.Fprintf(w, "📊 Score Progress: [%s] %d%% (%d/%d)\n", bar, percentage, current, target)
+}
+
+// ask runs a survey prompt and turns Ctrl-C into ErrInterrupted.
+func ask(p survey.Prompt, response interface{}, opts ...survey.AskOpt) error {
+	err := survey.AskOne(p, response, opts...)
+	if err == terminal.InterruptErr {
+		return ErrInterrupted
+	}
+	return err
 }
 
 func (cli *PokerCLI) Run() error {
-	sleepSec := 1
-	ClearTerminal()
+	game := NewGame(cli.service, cli)
+	game.Hints = cli.Hints
+	game.ProfileDir = cli.ProfileDir
+	game.RulesFile = cli.RulesFile
+	return game.Run()
+}
 
-	fmt.Println("*********************")
-	fmt.Println("* Welcome to Poker! *")
-	fmt.Println("*********************")
-	fmt.Println()
-	time.Sleep(time.Duration(sleepSec) * time.Second)
-
-	for {
-		ClearTerminal()
-		if cli.service.IsStartRound() {
-			rounds := cli.service.GetRounds()
-			ante := cli.service.GetCurrentAnteAmount()
-			blind := cli.service.GetCurrentBlindMulti()
-
-			printBox(
-				fmt.Sprintf("🃏 ROUND %d START", rounds),
-				fmt.Sprintf("Ante: %d  |  Blind: %.1f", ante, blind),
-			)
-			fmt.Println()
-			time.Sleep(time.Duration(sleepSec) * time.Second)
-
-			if err := cli.service.StartRound(); err != nil {
-				return err
-			}
-		}
+func (cli *PokerCLI) Start() error {
+	ClearTerminal()
 
-		roundStats := cli.service.GetRoundStats()
-		printProgressBar(roundStats.TotalScore, roundStats.ScoreAtLeast)
-		fmt.Printf("🃏 Hands: %d  |  🗑️  Discards: %d\n", roundStats.Hands, roundStats.Discards)
-		fmt.Println()
+	fmt.Fprintln(cli.out, "*********************")
+	fmt.Fprintln(cli.out, "* Welcome to Poker! *")
+	fmt.Fprintln(cli.out, "*********************")
+	fmt.Fprintln(cli.out)
+	time.Sleep(cli.sleep)
 
-		// Draw cards
-		drawNum := cli.service.GetNextDrawNum()
-		cards, err := cli.service.DrawCard(drawNum)
-		if err != nil {
-			return err
-		}
-		fmt.Printf("🎲 Draw %d cards\n", drawNum)
-		if cli.DebugMode {
-			fmt.Println("────────── Drawn Cards ──────────")
-			for _, card := range cards {
-				fmt.Printf("  • %s\n", card.String())
-			}
-			fmt.Println()
-		}
+	return nil
+}
 
-		// Select cards
-		var selectCards []string
-		for {
-			selectCards = nil
-			promptMs := &survey.MultiSelect{
-				Message: "Select cards",
-				Options: cli.service.GetHandCardString(),
-			}
-			err := survey.AskOne(promptMs, &selectCards, survey.WithPageSize(8))
-			if err == terminal.InterruptErr {
-				fmt.Println("interrupted")
-				os.Exit(0)
-			}
+func (cli *PokerCLI) Close() error {
+	return nil
+}
 
-			selectCardNum := len(selectCards)
-			if selectCardNum <= 5 {
-				break
-			}
-			fmt.Println("Please select less than 5 cards")
-			fmt.Println()
-		}
-		fmt.Println("✅ Selected Cards:")
-		if len(selectCards) > 0 {
-			for _, card := range selectCards {
-				fmt.Printf("  🃏 %s\n", card)
-			}
-		} else {
-			fmt.Println("  (No cards selected)")
+func (cli *PokerCLI) ShowState(state State) error {
+	ClearTerminal()
+	if state.NewRound {
+		title := fmt.Sprintf("🃏 ROUND %d START  (%s rules)", state.Rounds, state.RulesName)
+		if state.Challenge != nil {
+			title = fmt.Sprintf("🏆 ROUND %d START  (%s challenge)", state.Rounds, state.Challenge.Name)
 		}
-		fmt.Println()
-
-		// Play or Discard or Cancel
-		var selectAction string
-		actions := cli.service.GetEnableActions()
-		prompt := &survey.Select{
-			Message: "Select action:",
-			Options: actions,
+		printBox(cli.out, title, fmt.Sprintf("Ante: %d  |  Blind: %.1f", state.AnteAmount, state.BlindMulti))
+		if state.Boss != nil {
+			fmt.Fprintf(cli.out, "👹 Boss: %s - %s\n", state.Boss.Name, state.Boss.Description)
 		}
-		if err := survey.AskOne(prompt, &selectAction); err == terminal.InterruptErr {
-			fmt.Println("interrupted")
-			os.Exit(0)
+		for _, joker := range state.Jokers {
+			fmt.Fprintf(cli.out, "🃟 Joker: %s - %s\n", joker.Name, joker.Description)
 		}
+		fmt.Fprintln(cli.out)
+		time.Sleep(cli.sleep)
+	}
 
-		if err := cli.service.SelectCards(selectCards); err != nil {
-			return err
-		}
-		cli.service.SetAction(selectAction)
-		if selectAction == "Discard" {
-			if err := cli.service.DiscardHand(); err != nil {
-				return err
-			}
-			continue
+	printProgressBar(cli.out, state.Stats.TotalScore, state.Stats.ScoreAtLeast)
+	fmt.Fprintf(cli.out, "🃏 Hands: %d  |  🗑️  Discards: %d\n", state.Stats.Hands, state.Stats.Discards)
+	fmt.Fprintln(cli.out)
+
+	fmt.Fprintf(cli.out, "🎲 Draw %d cards\n", len(state.DrawnCards))
+	if cli.DebugMode {
+		fmt.Fprintln(cli.out, "────────── Drawn Cards ──────────")
+		for _, card := range state.DrawnCards {
+			fmt.Fprintf(cli.out, "  • %s\n", card.String())
 		}
-		if selectAction == "Cancel" {
-			if err := cli.service.CancelHand(); err != nil {
-				return err
-			}
-			continue
+		fmt.Fprintln(cli.out)
+	}
+
+	if state.Hint != nil {
+		cli.printHint(state)
+	}
+
+	return nil
+}
+
+func (cli *PokerCLI) printHint(state State) {
+	advice := state.Hint
+
+	fmt.Fprintln(cli.out, "────────── 💡 Hint ──────────")
+	fmt.Fprintf(cli.out, "  Best play: %s (Score: %d)\n", advice.PlayStats.HandType, advice.PlayStats.Score)
+	for _, i := range advice.Play {
+		fmt.Fprintf(cli.out, "    • %s\n", state.HandCards[i].String())
+	}
+	if state.Stats.Discards > 0 && len(advice.Discard) > 0 {
+		fmt.Fprintln(cli.out, "  Suggested discard:")
+		for _, i := range advice.Discard {
+			fmt.Fprintf(cli.out, "    • %s\n", state.HandCards[i].String())
 		}
-		if selectAction == "Play" {
-			r, err := cli.service.PlayHand()
-			if err != nil {
-				return err
-			}
+	}
+	fmt.Fprintf(cli.out, "  Chance to clear %d: %.0f%%\n", state.Stats.ScoreAtLeast, advice.ClearChance*100)
+	fmt.Fprintln(cli.out)
+}
+
+func (cli *PokerCLI) ShowMessage(message string) error {
+	fmt.Fprintln(cli.out, message)
+	fmt.Fprintln(cli.out)
+	return nil
+}
+
+func (cli *PokerCLI) AskSelection(state State) ([]entity.Trump, error) {
+	var options []string
+	for _, card := range state.HandCards {
+		options = append(options, card.String())
+	}
+
+	var selectCards []int
+	promptMs := &survey.MultiSelect{
+		Message: "Select cards",
+		Options: options,
+	}
+	if err := ask(promptMs, &selectCards, survey.WithPageSize(8)); err != nil {
+		return nil, err
+	}
 
-			fmt.Println("┌─────────────────────────────────────────┐")
-			fmt.Printf("│ 🎯 HAND RESULT: %-22s │\n", r.HandType)
-			fmt.Println("├─────────────────────────────────────────┤")
-			fmt.Printf("│ 💰 Chip: %-6d  |  ✨ Mult: %-6d │\n", r.Chip, r.Mult)
-			fmt.Printf("│ 🏆 Score: %-29d │\n", r.Score)
-			fmt.Println("└─────────────────────────────────────────┘")
-			fmt.Println()
+	var selected []entity.Trump
+	for _, i := range selectCards {
+		selected = append(selected, state.HandCards[i])
+	}
+	return selected, nil
+}
 
-			time.Sleep(time.Duration(sleepSec) * time.Second)
+func (cli *PokerCLI) AskAction(state State, selected []entity.Trump, preview *entity.PokerHandStats) (string, error) {
+	fmt.Fprintln(cli.out, "✅ Selected Cards:")
+	if len(selected) > 0 {
+		for _, card := range selected {
+			fmt.Fprintf(cli.out, "  🃏 %s\n", card.String())
 		}
+	} else {
+		fmt.Fprintln(cli.out, "  (No cards selected)")
+	}
+	fmt.Fprintln(cli.out)
 
-		// show remain cards
-		if cli.DebugMode {
-			fmt.Println("────────── Remaining Cards ──────────")
-			remainCards := cli.service.GetRemainCardString()
-			if len(remainCards) > 0 {
-				for _, card := range remainCards {
-					fmt.Printf("  • %s\n", card)
-				}
-			} else {
-				fmt.Println("  (No remaining cards)")
-			}
-			fmt.Println()
+	if preview != nil {
+		fmt.Fprintf(cli.out, "🔍 Preview: %s  |  💰 Chip: %d  |  ✨ Mult: %d  |  🏆 Score: %d\n",
+			preview.HandType, preview.Chip, preview.Mult, preview.Score)
+		for _, card := range preview.ScoringCards {
+			fmt.Fprintf(cli.out, "  ⭐ %s\n", card.String())
 		}
+		fmt.Fprintln(cli.out)
+	}
 
-		stats := cli.service.GetRoundStats()
-		if cli.service.IsRoundWin() {
-			fmt.Println("🎉 ROUND CLEAR! 🎉")
-			printProgressBar(stats.TotalScore, stats.ScoreAtLeast)
-			fmt.Println()
+	var selectAction string
+	prompt := &survey.Select{
+		Message: "Select action:",
+		Options: state.Actions,
+	}
+	if err := ask(prompt, &selectAction); err != nil {
+		return "", err
+	}
+	return selectAction, nil
+}
 
-			prompt := &survey.Select{
-				Message: "🏆 You win this round! Ready for next?",
-				Options: []string{"Next Round →"},
-			}
-			if err := survey.AskOne(prompt, &selectAction); err == terminal.InterruptErr {
-				fmt.Println("interrupted")
-				os.Exit(0)
-			}
-			if err := cli.service.NextRound(); err != nil {
-				return err
+func (cli *PokerCLI) ShowResult(state State, r entity.PokerHandStats) error {
+	fmt.Fprintln(cli.out, "┌─────────────────────────────────────────┐")
+	fmt.Fprintf(cli.out, "│ 🎯 HAND RESULT: %-22s │\n", r.HandType)
+	fmt.Fprintln(cli.out, "├─────────────────────────────────────────┤")
+	fmt.Fprintf(cli.out, "│ 💰 Chip: %-6d  |  ✨ Mult: %-6d │\n", r.Chip, r.Mult)
+	fmt.Fprintf(cli.out, "│ 🏆 Score: %-29d │\n", r.Score)
+	fmt.Fprintln(cli.out, "└─────────────────────────────────────────┘")
+	fmt.Fprintln(cli.out)
+
+	time.Sleep(cli.sleep)
+
+	// show remain cards
+	if cli.DebugMode {
+		fmt.Fprintln(cli.out, "────────── Remaining Cards ──────────")
+		if len(state.RemainCards) > 0 {
+			for _, card := range state.RemainCards {
+				fmt.Fprintf(cli.out, "  • %s\n", card.String())
 			}
-			continue
+		} else {
+			fmt.Fprintln(cli.out, "  (No remaining cards)")
 		}
+		fmt.Fprintln(cli.out)
+	}
 
-		if stats.Hands == 0 {
-			fmt.Println("💀 GAME OVER 💀")
-			printProgressBar(stats.TotalScore, stats.ScoreAtLeast)
-			fmt.Println("😢 Better luck next time!")
-			break
-		}
+	return nil
+}
+
+func (cli *PokerCLI) ShowAchievement(a achievement.Achievement) error {
+	fmt.Fprintf(cli.out, "🏅 Achievement unlocked: %s - %s\n", a.Name, a.Description)
+	fmt.Fprintln(cli.out)
+	time.Sleep(cli.sleep)
+	return nil
+}
+
+func (cli *PokerCLI) ShowRoundClear(state State) error {
+	fmt.Fprintln(cli.out, "🎉 ROUND CLEAR! 🎉")
+	printProgressBar(cli.out, state.Stats.TotalScore, state.Stats.ScoreAtLeast)
+	fmt.Fprintln(cli.out)
 
+	var selectAction string
+	prompt := &survey.Select{
+		Message: "🏆 You win this round! Ready for next?",
+		Options: []string{"Next Round →"},
 	}
+	return ask(prompt, &selectAction)
+}
 
+func (cli *PokerCLI) ShowGameOver(state State) error {
+	fmt.Fprintln(cli.out, "💀 GAME OVER 💀")
+	printProgressBar(cli.out, state.Stats.TotalScore, state.Stats.ScoreAtLeast)
+	fmt.Fprintln(cli.out, "😢 Better luck next time!")
+	if state.HintsUsed {
+		fmt.Fprintln(cli.out, "💡 Hints were used in this run")
+	}
+	return nil
+}
+
+func (cli *PokerCLI) ShowRunWon(state State) error {
+	fmt.Fprintln(cli.out, "🏆 RUN WON 🏆")
+	fmt.Fprintf(cli.out, "All %d antes cleared in %d rounds!\n", entity.WinAnte, state.Rounds)
+	if state.HintsUsed {
+		fmt.Fprintln(cli.out, "💡 Hints were used in this run")
+	}
 	return nil
 }
@@ -0,0 +1,174 @@
+package pkr
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/litencatt/pkr/achievement"
+	"github.com/litencatt/pkr/entity"
+	"github.com/litencatt/pkr/service"
+)
+
+// ErrIllegalMove is returned when a script makes a move the rules do not allow.
+var ErrIllegalMove = errors.New("illegal move")
+
+// PokerScript is a non-interactive frontend that reads moves from a script.
+// Each line is one move:
+//
+//	play 1 3 5    play the 1st, 3rd and 5th hand cards
+//	discard 2 4   discard the 2nd and 4th hand cards
+//	next          go to the next round after clearing one
+//
+// Blank lines and lines starting with # are ignored.
+type PokerScript struct {
+	service service.PokerService
+	scanner *bufio.Scanner
+	out     io.Writer
+	line    int
+	action  string
+}
+
+func NewPokerScript(script io.Reader, out io.Writer, config service.PokerServiceConfig) *PokerScript {
+	return &PokerScript{
+		service: service.NewPokerService(config),
+		scanner: bufio.NewScanner(script),
+		out:     out,
+	}
+}
+
+func (s *PokerScript) Run() error {
+	return NewGame(s.service, s).Run()
+}
+
+func (s *PokerScript) Start() error {
+	return nil
+}
+
+func (s *PokerScript) Close() error {
+	return nil
+}
+
+// nextMove returns the fields of the next move, or ErrQuit at the end of
+// the script.
+func (s *PokerScript) nextMove() ([]string, error) {
+	for s.scanner.Scan() {
+		s.line++
+		line := strings.TrimSpace(s.scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fmt.Fprintf(s.out, "> %s\n", line)
+		return strings.Fields(strings.ToLower(line)), nil
+	}
+	if err := s.scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintln(s.out, "script ended")
+	return nil, ErrQuit
+}
+
+func (s *PokerScript) illegal(format string, args ...interface{}) error {
+	return fmt.Errorf("%w at line %d: %s", ErrIllegalMove, s.line, fmt.Sprintf(format, args...))
+}
+
+func (s *PokerScript) ShowState(state State) error {
+	if state.NewRound {
+		fmt.Fprintf(s.out, "ROUND %d START  Ante: %d  Blind: %.1f\n", state.Rounds, state.AnteAmount, state.BlindMulti)
+		if state.Boss != nil {
+			fmt.Fprintf(s.out, "BOSS %s\n", state.Boss.Name)
+		}
+	}
+	fmt.Fprintf(s.out, "Score: %d/%d  Hands: %d  Discards: %d\n",
+		state.Stats.TotalScore, state.Stats.ScoreAtLeast, state.Stats.Hands, state.Stats.Discards)
+
+	var cards []string
+	for i, card := range state.HandCards {
+		cards = append(cards, fmt.Sprintf("%d:%s%s", i+1, cardRank(card), suitSymbols[card.Suit]))
+	}
+	fmt.Fprintf(s.out, "Hand: %s\n", strings.Join(cards, " "))
+	return nil
+}
+
+func (s *PokerScript) ShowMessage(message string) error {
+	fmt.Fprintln(s.out, message)
+	return nil
+}
+
+func (s *PokerScript) AskSelection(state State) ([]entity.Trump, error) {
+	move, err := s.nextMove()
+	if err != nil {
+		return nil, err
+	}
+
+	switch move[0] {
+	case "play":
+		s.action = "Play"
+	case "discard":
+		s.action = "Discard"
+		if !containsAction(state.Actions, "Discard") {
+			return nil, s.illegal("no discards left")
+		}
+	default:
+		return nil, s.illegal("expected play or discard, got %q", move[0])
+	}
+
+	if n := len(move) - 1; n == 0 || n > state.MaxSelectCards {
+		return nil, s.illegal("select 1 to %d cards, got %d", state.MaxSelectCards, n)
+	}
+
+	var selected []entity.Trump
+	for _, field := range move[1:] {
+		i, err := strconv.Atoi(field)
+		if err != nil || i < 1 || i > len(state.HandCards) {
+			return nil, s.illegal("card %q is not between 1 and %d", field, len(state.HandCards))
+		}
+		card := state.HandCards[i-1]
+		if entity.Contains(selected, card) {
+			return nil, s.illegal("card %d selected twice", i)
+		}
+		selected = append(selected, card)
+	}
+	return selected, nil
+}
+
+func (s *PokerScript) AskAction(state State, selected []entity.Trump, preview *entity.PokerHandStats) (string, error) {
+	return s.action, nil
+}
+
+func (s *PokerScript) ShowResult(state State, r entity.PokerHandStats) error {
+	fmt.Fprintf(s.out, "%s  Chip: %d  Mult: %d  Score: %d\n", r.HandType, r.Chip, r.Mult, r.Score)
+	return nil
+}
+
+func (s *PokerScript) ShowAchievement(a achievement.Achievement) error {
+	fmt.Fprintf(s.out, "ACHIEVEMENT  %s\n", a.Name)
+	return nil
+}
+
+func (s *PokerScript) ShowRoundClear(state State) error {
+	fmt.Fprintf(s.out, "ROUND CLEAR  Score: %d/%d\n", state.Stats.TotalScore, state.Stats.ScoreAtLeast)
+
+	move, err := s.nextMove()
+	if err != nil {
+		return err
+	}
+	if move[0] != "next" || len(move) != 1 {
+		return s.illegal("expected next after clearing the round, got %q", strings.Join(move, " "))
+	}
+	return nil
+}
+
+func (s *PokerScript) ShowGameOver(state State) error {
+	fmt.Fprintf(s.out, "GAME OVER  Score: %d/%d\n", state.Stats.TotalScore, state.Stats.ScoreAtLeast)
+	return nil
+}
+
+func (s *PokerScript) ShowRunWon(state State) error {
+	fmt.Fprintf(s.out, "RUN WON  Rounds: %d\n", state.Rounds)
+	return nil
+}
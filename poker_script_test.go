@@ -0,0 +1,77 @@
+package pkr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/litencatt/pkr/service"
+)
+
+func runScript(t *testing.T, script string) (string, error) {
+	t.Helper()
+	seed := int64(42)
+	var out strings.Builder
+	err := NewPokerScript(strings.NewReader(script), &out, service.PokerServiceConfig{Seed: &seed}).Run()
+	return out.String(), err
+}
+
+func TestPokerScriptClearRound(t *testing.T) {
+	out, err := runScript(t, `# clears round 1 with seed 42
+play 1 2 3 4 5
+play 1 2 3 4 5
+play 1 2 3 4 5
+play 1 2 3 4 5
+next
+discard 1
+`)
+	if err != nil {
+		t.Fatalf("Run() returned error: %v\n%s", err, out)
+	}
+
+	for _, want := range []string{"ROUND CLEAR  Score: 316/300", "ROUND 2 START", "> discard 1", "script ended"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output should contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestPokerScriptIllegalMoves(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+	}{
+		{"unknown move", "fold 1\n"},
+		{"no cards", "play\n"},
+		{"too many cards", "play 1 2 3 4 5 6\n"},
+		{"out of range", "discard 9\n"},
+		{"not a number", "play one\n"},
+		{"selected twice", "play 1 1\n"},
+		{"no discards left", "discard 1\ndiscard 1\ndiscard 1\ndiscard 1\n"},
+		{"next before clear", "next\n"},
+		{"play after clear", "play 1 2 3 4 5\nplay 1 2 3 4 5\nplay 1 2 3 4 5\nplay 1 2 3 4 5\nplay 1\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := runScript(t, tt.script)
+			if !errors.Is(err, ErrIllegalMove) {
+				t.Errorf("Run() error = %v, want %v\n%s", err, ErrIllegalMove, out)
+			}
+		})
+	}
+}
+
+func TestPokerScriptGameOver(t *testing.T) {
+	out, err := runScript(t, "play 1\nplay 1\nplay 1\nplay 1\nplay 1\n")
+	if err != nil {
+		t.Fatalf("Run() returned error: %v\n%s", err, out)
+	}
+
+	if !strings.Contains(out, "GAME OVER") {
+		t.Errorf("Output should contain GAME OVER, got:\n%s", out)
+	}
+	if strings.Count(out, "> play") != 4 {
+		t.Errorf("Moves after game over should not be read, got:\n%s", out)
+	}
+}
@@ -0,0 +1,451 @@
+package pkr
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/litencatt/pkr/achievement"
+	"github.com/litencatt/pkr/entity"
+	"github.com/litencatt/pkr/service"
+)
+
+const (
+	ansiReset     = "\x1b[0m"
+	ansiRed       = "\x1b[31m"
+	ansiYellow    = "\x1b[33m"
+	ansiBold      = "\x1b[1m"
+	ansiClearLine = "\x1b[K"
+	ansiClearDown = "\x1b[J"
+	ansiHome      = "\x1b[H"
+)
+
+var suitSymbols = map[entity.Suit]string{
+	entity.Clubs:    "♣",
+	entity.Diamonds: "♦",
+	entity.Hearts:   "♥",
+	entity.Spades:   "♠",
+}
+
+var suitOrder = map[entity.Suit]int{
+	entity.Spades:   0,
+	entity.Hearts:   1,
+	entity.Clubs:    2,
+	entity.Diamonds: 3,
+}
+
+// PokerTUI is a full-screen keyboard-driven frontend.
+type PokerTUI struct {
+	DebugMode  bool
+	Hints      bool
+	ProfileDir string
+	RulesFile  string
+	service    service.PokerService
+
+	in    *os.File
+	out   io.Writer
+	state *term.State
+
+	view     State
+	cards    []entity.Trump
+	selected map[entity.Trump]bool
+	cursor   int
+	sortBy   string
+	action   string
+	message  string
+}
+
+func NewPokerTUI(config service.PokerServiceConfig) *PokerTUI {
+	return &PokerTUI{
+		service:  service.NewPokerService(config),
+		in:       os.Stdin,
+		out:      os.Stdout,
+		selected: make(map[entity.Trump]bool),
+		sortBy:   "rank",
+	}
+}
+
+func (t *PokerTUI) Run() error {
+	game := NewGame(t.service, t)
+	game.Hints = t.Hints
+	game.ProfileDir = t.ProfileDir
+	game.RulesFile = t.RulesFile
+	return game.Run()
+}
+
+func (t *PokerTUI) Start() error {
+	fd := int(t.in.Fd())
+	if !term.IsTerminal(fd) {
+		return fmt.Errorf("the tui needs an interactive terminal")
+	}
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	t.state = state
+
+	// Use the alternate screen and hide the cursor while playing
+	fmt.Fprint(t.out, "\x1b[?1049h\x1b[?25l")
+	return nil
+}
+
+func (t *PokerTUI) Close() error {
+	if t.state == nil {
+		return nil
+	}
+	fmt.Fprint(t.out, "\x1b[?25h\x1b[?1049l")
+	return term.Restore(int(t.in.Fd()), t.state)
+}
+
+func (t *PokerTUI) ShowState(state State) error {
+	t.view = state
+	if state.NewRound {
+		t.message = fmt.Sprintf("ROUND %d START  Ante: %d  Blind: %.1f", state.Rounds, state.AnteAmount, state.BlindMulti)
+		if state.Boss != nil {
+			t.message += fmt.Sprintf("  Boss: %s - %s", state.Boss.Name, state.Boss.Description)
+		}
+	}
+
+	// Keep the selection of cards still in hand
+	for card := range t.selected {
+		if !entity.Contains(state.HandCards, card) {
+			delete(t.selected, card)
+		}
+	}
+	t.sortCards()
+	t.render()
+	return nil
+}
+
+func (t *PokerTUI) ShowMessage(message string) error {
+	t.message = message
+	t.render()
+	return nil
+}
+
+// AskSelection handles key input until the player plays, discards or asks
+// for a hint. The chosen action is returned by AskAction.
+func (t *PokerTUI) AskSelection(state State) ([]entity.Trump, error) {
+	for {
+		t.render()
+
+		key, err := t.readKey()
+		if err != nil {
+			return nil, err
+		}
+
+		switch key {
+		case "q":
+			return nil, ErrQuit
+		case "ctrl+c":
+			return nil, ErrInterrupted
+		case "left":
+			if t.cursor > 0 {
+				t.cursor--
+			}
+		case "right":
+			if t.cursor < len(t.cards)-1 {
+				t.cursor++
+			}
+		case "space":
+			t.toggle(t.cursor)
+		case "r":
+			t.sortBy = "rank"
+			t.sortCards()
+		case "s":
+			t.sortBy = "suit"
+			t.sortCards()
+		case "h":
+			if containsAction(state.Actions, "Hint") {
+				t.action = "Hint"
+				return t.selectedCards(), nil
+			}
+		case "p", "d":
+			if len(t.selected) == 0 {
+				t.message = fmt.Sprintf("Select 1 to %d cards", state.MaxSelectCards)
+				continue
+			}
+			t.action = "Play"
+			if key == "d" {
+				if !containsAction(state.Actions, "Discard") {
+					t.message = "No discards left"
+					continue
+				}
+				t.action = "Discard"
+			}
+			selected := t.selectedCards()
+			t.selected = make(map[entity.Trump]bool)
+			return selected, nil
+		default:
+			if len(key) == 1 && key[0] >= '1' && key[0] <= '9' {
+				i := int(key[0] - '1')
+				if i < len(t.cards) {
+					t.cursor = i
+					t.toggle(i)
+				}
+			}
+		}
+	}
+}
+
+func (t *PokerTUI) AskAction(state State, selected []entity.Trump, preview *entity.PokerHandStats) (string, error) {
+	return t.action, nil
+}
+
+func (t *PokerTUI) ShowResult(state State, r entity.PokerHandStats) error {
+	t.view = state
+	t.message = fmt.Sprintf("%s  Chip: %d  Mult: %d  Score: %d", r.HandType, r.Chip, r.Mult, r.Score)
+	t.render()
+	return nil
+}
+
+func (t *PokerTUI) ShowAchievement(a achievement.Achievement) error {
+	t.message += fmt.Sprintf("  🏅 %s unlocked!", a.Name)
+	t.render()
+	return nil
+}
+
+func (t *PokerTUI) ShowRoundClear(state State) error {
+	t.view = state
+	t.cards = nil
+	t.message += "  ROUND CLEAR! Press Enter for the next round"
+	return t.waitKey("enter")
+}
+
+func (t *PokerTUI) ShowGameOver(state State) error {
+	t.view = state
+	t.cards = nil
+	t.message += "  GAME OVER  Better luck next time!"
+	if state.HintsUsed {
+		t.message += " (hints used)"
+	}
+	t.message += " Press any key"
+	return t.waitKey("")
+}
+
+func (t *PokerTUI) ShowRunWon(state State) error {
+	t.view = state
+	t.cards = nil
+	t.message += fmt.Sprintf("  RUN WON  All %d antes cleared!", entity.WinAnte)
+	if state.HintsUsed {
+		t.message += " (hints used)"
+	}
+	t.message += " Press any key"
+	return t.waitKey("")
+}
+
+func (t *PokerTUI) selectedCards() []entity.Trump {
+	var selected []entity.Trump
+	for _, card := range t.cards {
+		if t.selected[card] {
+			selected = append(selected, card)
+		}
+	}
+	return selected
+}
+
+func (t *PokerTUI) toggle(i int) {
+	if i >= len(t.cards) {
+		return
+	}
+	card := t.cards[i]
+	if t.selected[card] {
+		delete(t.selected, card)
+		return
+	}
+	if len(t.selected) >= t.view.MaxSelectCards {
+		t.message = fmt.Sprintf("You can select up to %d cards", t.view.MaxSelectCards)
+		return
+	}
+	t.selected[card] = true
+}
+
+func (t *PokerTUI) sortCards() {
+	t.cards = append([]entity.Trump(nil), t.view.HandCards...)
+	sort.SliceStable(t.cards, func(i, j int) bool {
+		a, b := t.cards[i], t.cards[j]
+		if t.sortBy == "suit" && a.Suit != b.Suit {
+			return suitOrder[a.Suit] < suitOrder[b.Suit]
+		}
+		return a.GetSortOrder() < b.GetSortOrder()
+	})
+	if t.cursor >= len(t.cards) {
+		t.cursor = len(t.cards) - 1
+	}
+	if t.cursor < 0 {
+		t.cursor = 0
+	}
+}
+
+// waitKey waits for the given key, or any key if key is empty.
+func (t *PokerTUI) waitKey(key string) error {
+	for {
+		t.render()
+		got, err := t.readKey()
+		if err != nil {
+			return err
+		}
+		if got == "ctrl+c" {
+			return ErrInterrupted
+		}
+		if got == "q" {
+			return ErrQuit
+		}
+		if key == "" || got == key {
+			return nil
+		}
+	}
+}
+
+func (t *PokerTUI) readKey() (string, error) {
+	buf := make([]byte, 8)
+	n, err := t.in.Read(buf)
+	if err != nil {
+		return "", err
+	}
+
+	switch s := string(buf[:n]); s {
+	case "\x1b[D":
+		return "left", nil
+	case "\x1b[C":
+		return "right", nil
+	case "\r", "\n":
+		return "enter", nil
+	case " ":
+		return "space", nil
+	case "\x03":
+		return "ctrl+c", nil
+	default:
+		return strings.ToLower(s), nil
+	}
+}
+
+func (t *PokerTUI) render() {
+	stats := t.view.Stats
+
+	header := fmt.Sprintf(" ROUND %d  |  Ante: %d  |  Blind: %.1f  |  Rules: %s",
+		t.view.Rounds, t.view.AnteAmount, t.view.BlindMulti, t.view.RulesName)
+	if t.view.Challenge != nil {
+		header += "  |  Challenge: " + t.view.Challenge.Name
+	}
+
+	var lines []string
+	lines = append(lines,
+		ansiBold+header+ansiReset,
+		"",
+		" "+progressBar(stats.TotalScore, stats.ScoreAtLeast),
+		fmt.Sprintf(" Hands: %d  |  Discards: %d  |  Deck: %d", stats.Hands, stats.Discards, t.view.DeckRemaining),
+	)
+	var extras, jokers []string
+	for _, joker := range t.view.Jokers {
+		jokers = append(jokers, joker.Name)
+	}
+	if len(jokers) > 0 {
+		extras = append(extras, "Jokers: "+strings.Join(jokers, ", "))
+	}
+	if t.view.Boss != nil {
+		extras = append(extras, "Boss: "+t.view.Boss.Name)
+	}
+	lines = append(lines, " "+strings.Join(extras, "  |  "), "")
+
+	lines = append(lines, renderCards(t.cards, t.selected)...)
+
+	var marks, numbers string
+	for i := range t.cards {
+		mark := "       "
+		if i == t.cursor {
+			mark = "   ▲   "
+		}
+		marks += mark + " "
+		numbers += fmt.Sprintf("  [%d]   ", i+1)
+	}
+	lines = append(lines, marks, numbers, "")
+
+	preview := ""
+	if selected := t.selectedCards(); len(selected) > 0 && t.view.Preview != nil {
+		if p, err := t.view.Preview(selected); err == nil {
+			preview = fmt.Sprintf("Preview: %s  |  Chip: %d  |  Mult: %d  |  Score: %d", p.HandType, p.Chip, p.Mult, p.Score)
+		}
+	}
+	hint := ""
+	if advice := t.view.Hint; advice != nil && len(t.cards) > 0 {
+		var play []string
+		for _, i := range advice.Play {
+			play = append(play, cardLabel(t.view.HandCards[i]))
+		}
+		hint = fmt.Sprintf("Hint: %s (%d) with %s  |  Chance to clear: %.0f%%",
+			advice.PlayStats.HandType, advice.PlayStats.Score, strings.Join(play, " "), advice.ClearChance*100)
+	}
+	lines = append(lines, " "+preview, " "+hint, " "+ansiYellow+t.message+ansiReset, "",
+		" ←/→ move  space/1-8 select  p play  d discard  r/s sort by rank/suit  h hint  q quit")
+
+	var b strings.Builder
+	b.WriteString(ansiHome)
+	for _, line := range lines {
+		b.WriteString(line + ansiClearLine + "\r\n")
+	}
+	b.WriteString(ansiClearDown)
+	fmt.Fprint(t.out, b.String())
+}
+
+// renderCards lays out the cards horizontally as ASCII art. Selected cards
+// are raised by one line.
+func renderCards(cards []entity.Trump, selected map[entity.Trump]bool) []string {
+	lines := make([]string, 6)
+	for _, card := range cards {
+		rank := cardRank(card)
+		art := []string{
+			"┌─────┐",
+			fmt.Sprintf("│%-2s   │", rank),
+			fmt.Sprintf("│  %s  │", suitSymbols[card.Suit]),
+			fmt.Sprintf("│   %2s│", rank),
+			"└─────┘",
+		}
+
+		color := ""
+		if card.Suit == entity.Hearts || card.Suit == entity.Diamonds {
+			color = ansiRed
+		}
+		if selected[card] {
+			color += ansiBold
+			art = append(art, "       ")
+		} else {
+			art = append([]string{"       "}, art...)
+		}
+
+		for i := range lines {
+			lines[i] += color + art[i] + ansiReset + " "
+		}
+	}
+	return lines
+}
+
+func cardRank(card entity.Trump) string {
+	if card.Rank == entity.Ten {
+		return "10"
+	}
+	return string(card.Rank)
+}
+
+func cardLabel(card entity.Trump) string {
+	return cardRank(card) + suitSymbols[card.Suit]
+}
+
+func progressBar(current, target int) string {
+	barWidth := 30
+	var progress float64
+	if target > 0 {
+		progress = float64(current) / float64(target)
+		if progress > 1.0 {
+			progress = 1.0
+		}
+	}
+
+	filled := int(progress * float64(barWidth))
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+	return fmt.Sprintf("Score: [%s] %d%% (%d/%d)", bar, int(progress*100), current, target)
+}
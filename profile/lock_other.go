@@ -0,0 +1,13 @@
+//go:build !unix
+
+package profile
+
+import "os"
+
+// lock only creates dir on platforms without flock.
+func lock(dir string) (func(), error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	return func() {}, nil
+}
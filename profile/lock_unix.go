@@ -0,0 +1,30 @@
+//go:build unix
+
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// lock takes an exclusive lock on dir so that concurrent pkr processes do
+// not overwrite each other's updates.
+func lock(dir string) (func(), error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(filepath.Join(dir, ".lock"), os.O_CREATE|os.O_RDWR, 0o600) // #nosec G304 -- lock path is built from the data directory
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return func() {
+		_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
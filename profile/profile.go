@@ -0,0 +1,239 @@
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/litencatt/pkr/achievement"
+	"github.com/litencatt/pkr/entity"
+	"github.com/litencatt/pkr/videopoker"
+)
+
+const fileName = "profile.json"
+
+// Profile is the player statistics accumulated across runs.
+type Profile struct {
+	RunsPlayed       int                     `json:"runs_played"`
+	RunsWon          int                     `json:"runs_won"`
+	BestAnte         int                     `json:"best_ante"`
+	HighestHandScore int                     `json:"highest_hand_score"`
+	HandTypes        map[entity.HandType]int `json:"hand_types"`
+	Decks            map[string]int          `json:"decks"`
+	FavoriteDeck     string                  `json:"favorite_deck"`
+	CardsDiscarded   int                     `json:"cards_discarded"`
+	HintedRuns       int                     `json:"hinted_runs"`
+	Achievements     achievement.Progress    `json:"achievements"`
+	// Challenges are keyed by challenge ID.
+	Challenges map[string]*ChallengeProgress `json:"challenges"`
+	// VideoPoker is keyed by machine and paytable, like "Deuces Wild
+	// full-pay".
+	VideoPoker map[string]*videopoker.Stats `json:"video_poker"`
+}
+
+// ChallengeProgress is the progress of one challenge.
+type ChallengeProgress struct {
+	Attempts int `json:"attempts"`
+	BestAnte int `json:"best_ante"`
+	// Completed is when the challenge was first won, if it was.
+	Completed *time.Time `json:"completed,omitempty"`
+}
+
+// Run is the summary of a finished run.
+type Run struct {
+	// Date is when the run ended.
+	Date time.Time
+	// Challenge is the ID of the challenge played, if any.
+	Challenge string
+	Deck      string
+	Won       bool
+	Ante      int
+	HintsUsed bool
+	Stats     entity.RunStats
+}
+
+// New returns an empty profile.
+func New() *Profile {
+	return &Profile{
+		HandTypes:    make(map[entity.HandType]int),
+		Decks:        make(map[string]int),
+		Achievements: achievement.NewProgress(),
+		Challenges:   make(map[string]*ChallengeProgress),
+		VideoPoker:   make(map[string]*videopoker.Stats),
+	}
+}
+
+// Dir returns the directory the profile is stored in, $XDG_DATA_HOME/pkr or
+// ~/.local/share/pkr if XDG_DATA_HOME is not set.
+func Dir() (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "pkr"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share", "pkr"), nil
+}
+
+// Load reads the profile in dir. An empty profile is returned if none has
+// been saved yet.
+func Load(dir string) (*Profile, error) {
+	p := New()
+	data, err := os.ReadFile(filepath.Join(dir, fileName)) // #nosec G304 -- profile path is built from the data directory
+	if errors.Is(err, fs.ErrNotExist) {
+		return p, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("parse profile %s: %w", filepath.Join(dir, fileName), err)
+	}
+	if p.HandTypes == nil {
+		p.HandTypes = make(map[entity.HandType]int)
+	}
+	if p.Decks == nil {
+		p.Decks = make(map[string]int)
+	}
+	if p.Achievements.Counts == nil {
+		p.Achievements.Counts = make(map[string]int)
+	}
+	if p.Achievements.Unlocked == nil {
+		p.Achievements.Unlocked = make(map[string]time.Time)
+	}
+	if p.Challenges == nil {
+		p.Challenges = make(map[string]*ChallengeProgress)
+	}
+	if p.VideoPoker == nil {
+		p.VideoPoker = make(map[string]*videopoker.Stats)
+	}
+	return p, nil
+}
+
+// Save writes the profile into dir, replacing the previous one.
+func (p *Profile) Save(dir string) error {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a crash never leaves a broken profile
+	tmp, err := os.CreateTemp(dir, fileName+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, fileName))
+}
+
+// Record adds a finished run to the profile.
+func (p *Profile) Record(run Run) {
+	p.RunsPlayed++
+	if run.Won {
+		p.RunsWon++
+	}
+	if run.Ante > p.BestAnte {
+		p.BestAnte = run.Ante
+	}
+	if run.Stats.BestHandScore > p.HighestHandScore {
+		p.HighestHandScore = run.Stats.BestHandScore
+	}
+	for handType, n := range run.Stats.HandTypes {
+		p.HandTypes[handType] += n
+	}
+	p.Decks[run.Deck]++
+	p.FavoriteDeck = p.favoriteDeck()
+	p.CardsDiscarded += run.Stats.CardsDiscarded
+	if run.HintsUsed {
+		p.HintedRuns++
+	}
+
+	if run.Challenge != "" {
+		c := p.Challenges[run.Challenge]
+		if c == nil {
+			c = &ChallengeProgress{}
+			p.Challenges[run.Challenge] = c
+		}
+		c.Attempts++
+		if run.Ante > c.BestAnte {
+			c.BestAnte = run.Ante
+		}
+		if run.Won && c.Completed == nil {
+			date := run.Date
+			c.Completed = &date
+		}
+	}
+}
+
+// RecordVideoPoker adds a video poker session to the totals of its machine
+// and paytable.
+func (p *Profile) RecordVideoPoker(key string, stats videopoker.Stats) {
+	total := p.VideoPoker[key]
+	if total == nil {
+		total = &videopoker.Stats{}
+		p.VideoPoker[key] = total
+	}
+	total.Add(stats)
+}
+
+// SortedHandTypes returns the played hand types from weakest to strongest.
+func (p *Profile) SortedHandTypes() []entity.HandType {
+	var handTypes []entity.HandType
+	for handType := range p.HandTypes {
+		handTypes = append(handTypes, handType)
+	}
+	sort.Slice(handTypes, func(i, j int) bool {
+		return entity.GetScore(handTypes[i]) < entity.GetScore(handTypes[j])
+	})
+	return handTypes
+}
+
+// favoriteDeck returns the deck played most, preferring the first name in
+// alphabetical order on a tie.
+func (p *Profile) favoriteDeck() string {
+	favorite := ""
+	for deck, n := range p.Decks {
+		if n > p.Decks[favorite] || (n == p.Decks[favorite] && deck < favorite) {
+			favorite = deck
+		}
+	}
+	return favorite
+}
+
+// Update loads the profile in dir, changes it with update and saves it. The
+// directory is locked while updating.
+func Update(dir string, update func(*Profile)) (*Profile, error) {
+	unlock, err := lock(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	p, err := Load(dir)
+	if err != nil {
+		return nil, err
+	}
+	update(p)
+	if err := p.Save(dir); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
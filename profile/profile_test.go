@@ -0,0 +1,152 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/litencatt/pkr/entity"
+	"github.com/litencatt/pkr/videopoker"
+)
+
+func TestRecord(t *testing.T) {
+	p := New()
+	p.Record(Run{
+		Deck: "Red",
+		Ante: 2,
+		Stats: entity.RunStats{
+			HandTypes:      map[entity.HandType]int{entity.OnePair: 3, entity.Flush: 1},
+			BestHandScore:  120,
+			CardsDiscarded: 7,
+		},
+	})
+	p.Record(Run{
+		Deck:      "Blue",
+		Won:       true,
+		Ante:      9,
+		HintsUsed: true,
+		Stats: entity.RunStats{
+			HandTypes:      map[entity.HandType]int{entity.OnePair: 2},
+			BestHandScore:  80,
+			CardsDiscarded: 3,
+		},
+	})
+
+	if p.RunsPlayed != 2 || p.RunsWon != 1 {
+		t.Errorf("RunsPlayed, RunsWon = %d, %d, want 2, 1", p.RunsPlayed, p.RunsWon)
+	}
+	if p.BestAnte != 9 {
+		t.Errorf("BestAnte = %d, want 9", p.BestAnte)
+	}
+	if p.HighestHandScore != 120 {
+		t.Errorf("HighestHandScore = %d, want 120", p.HighestHandScore)
+	}
+	if p.HandTypes[entity.OnePair] != 5 || p.HandTypes[entity.Flush] != 1 {
+		t.Errorf("HandTypes = %v", p.HandTypes)
+	}
+	if p.CardsDiscarded != 10 {
+		t.Errorf("CardsDiscarded = %d, want 10", p.CardsDiscarded)
+	}
+	if p.HintedRuns != 1 {
+		t.Errorf("HintedRuns = %d, want 1", p.HintedRuns)
+	}
+	// Ties go to the first deck in alphabetical order
+	if p.FavoriteDeck != "Blue" {
+		t.Errorf("FavoriteDeck = %q, want Blue", p.FavoriteDeck)
+	}
+
+	p.Record(Run{Deck: "Red", Stats: entity.RunStats{}})
+	if p.FavoriteDeck != "Red" {
+		t.Errorf("FavoriteDeck = %q, want Red", p.FavoriteDeck)
+	}
+
+	handTypes := p.SortedHandTypes()
+	if len(handTypes) != 2 || handTypes[0] != entity.OnePair {
+		t.Errorf("SortedHandTypes() = %v, want [One Pair Flush]", handTypes)
+	}
+}
+
+func TestRecordChallenge(t *testing.T) {
+	p := New()
+	date := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	p.Record(Run{Challenge: "mindful", Deck: "Standard", Ante: 3})
+	p.Record(Run{Challenge: "mindful", Deck: "Standard", Ante: 9, Won: true, Date: date})
+	p.Record(Run{Challenge: "mindful", Deck: "Standard", Ante: 9, Won: true, Date: date.AddDate(0, 0, 1)})
+
+	c := p.Challenges["mindful"]
+	if c == nil || c.Attempts != 3 || c.BestAnte != 9 {
+		t.Fatalf("Challenges[mindful] = %+v, want 3 attempts and best ante 9", c)
+	}
+	// The first win is kept
+	if c.Completed == nil || !c.Completed.Equal(date) {
+		t.Errorf("Completed = %v, want %v", c.Completed, date)
+	}
+	if p.RunsPlayed != 3 {
+		t.Errorf("RunsPlayed = %d, want 3", p.RunsPlayed)
+	}
+}
+
+func TestRecordVideoPoker(t *testing.T) {
+	p := New()
+	p.RecordVideoPoker("Deuces Wild full-pay", videopoker.Stats{Sessions: 1, Hands: 10, Wagered: 50, Won: 40, BestWin: 25, HandTypes: map[videopoker.Category]int{videopoker.Flush: 1}})
+	p.RecordVideoPoker("Deuces Wild full-pay", videopoker.Stats{Sessions: 1, Hands: 4, Wagered: 20, Won: 10, BestWin: 10})
+	p.RecordVideoPoker("Jacks or Better 9/6", videopoker.Stats{Sessions: 1, Hands: 1, Wagered: 5})
+
+	s := p.VideoPoker["Deuces Wild full-pay"]
+	if s == nil || s.Sessions != 2 || s.Hands != 14 || s.Wagered != 70 || s.Won != 50 || s.BestWin != 25 {
+		t.Fatalf("VideoPoker[Deuces Wild full-pay] = %+v", s)
+	}
+	if s.HandTypes[videopoker.Flush] != 1 {
+		t.Errorf("HandTypes = %v", s.HandTypes)
+	}
+	if len(p.VideoPoker) != 2 {
+		t.Errorf("VideoPoker has %d machines, want 2", len(p.VideoPoker))
+	}
+}
+
+func TestLoadAndSave(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "pkr")
+
+	p, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() of a missing profile returned error: %v", err)
+	}
+	if p.RunsPlayed != 0 {
+		t.Errorf("RunsPlayed = %d, want 0", p.RunsPlayed)
+	}
+
+	p, err = Update(dir, func(p *Profile) {
+		p.Record(Run{Deck: "Standard", Ante: 3, Stats: entity.RunStats{BestHandScore: 50}})
+	})
+	if err != nil {
+		t.Fatalf("Update() returned error: %v", err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if loaded.RunsPlayed != 1 || loaded.BestAnte != 3 || loaded.FavoriteDeck != "Standard" {
+		t.Errorf("Load() = %+v, want %+v", loaded, p)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, fileName), []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(dir); err == nil {
+		t.Error("Load() of a broken profile should return error")
+	}
+}
+
+func TestDir(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", "/tmp/data")
+
+	dir, err := Dir()
+	if err != nil {
+		t.Fatalf("Dir() returned error: %v", err)
+	}
+	if dir != filepath.Join("/tmp/data", "pkr") {
+		t.Errorf("Dir() = %q, want /tmp/data/pkr", dir)
+	}
+}
@@ -0,0 +1,179 @@
+package profile
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/litencatt/pkr/entity"
+)
+
+const scoresFileName = "scores.jsonl"
+
+// Score is a leaderboard entry of a finished run.
+type Score struct {
+	Date time.Time `json:"date"`
+	Seed *int64    `json:"seed,omitempty"`
+	Deck string    `json:"deck"`
+	// DeckID is the ID of a pack deck, empty for built-in decks, which are
+	// found by their name.
+	DeckID string `json:"deck_id,omitempty"`
+	Stake  string `json:"stake"`
+	// Jokers are the IDs of the jokers the run was started with on top of
+	// the jokers of the deck.
+	Jokers []string `json:"jokers,omitempty"`
+	// Rules is the name of the ruleset, empty for runs recorded before
+	// rulesets were.
+	Rules string `json:"rules,omitempty"`
+	// RulesFile is the file the rules were read from, empty for the
+	// standard rules.
+	RulesFile string `json:"rules_file,omitempty"`
+	Ante      int    `json:"ante"`
+	Rounds    int    `json:"rounds"`
+	BestHand  int    `json:"best_hand"`
+	Won       bool   `json:"won"`
+	// Replay is the path of the script that replays the run.
+	Replay string `json:"replay,omitempty"`
+}
+
+// RulesName returns the name of the ruleset of the run.
+func (s Score) RulesName() string {
+	if s.Rules == "" {
+		return entity.DefaultRules().Name
+	}
+	return s.Rules
+}
+
+// ScoreFilter selects leaderboard entries. Empty fields match everything.
+type ScoreFilter struct {
+	Deck  string
+	Stake string
+	Rules string
+}
+
+func (f ScoreFilter) match(s Score) bool {
+	return (f.Deck == "" || strings.EqualFold(f.Deck, s.Deck) || strings.EqualFold(f.Deck, s.DeckID)) &&
+		(f.Stake == "" || strings.EqualFold(f.Stake, s.Stake)) &&
+		(f.Rules == "" || strings.EqualFold(f.Rules, s.RulesName()))
+}
+
+// AddScore appends the score to the leaderboard in dir. The directory is
+// locked while writing, so concurrent processes can add scores safely.
+func AddScore(dir string, score Score) error {
+	unlock, err := lock(dir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	data, err := json.Marshal(score)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(dir, scoresFileName), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600) // #nosec G304 -- scores path is built from the data directory
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LoadScores reads all leaderboard entries in dir.
+func LoadScores(dir string) ([]Score, error) {
+	path := filepath.Join(dir, scoresFileName)
+	f, err := os.Open(path) // #nosec G304 -- scores path is built from the data directory
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var scores []Score
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var score Score
+		if err := json.Unmarshal(scanner.Bytes(), &score); err != nil {
+			return nil, fmt.Errorf("parse %s line %d: %w", path, line, err)
+		}
+		scores = append(scores, score)
+	}
+	return scores, scanner.Err()
+}
+
+// TopScores returns up to limit scores matching the filter, ordered by ante
+// reached and then best hand. Older runs come first on a tie.
+func TopScores(scores []Score, filter ScoreFilter, limit int) []Score {
+	var top []Score
+	for _, s := range scores {
+		if filter.match(s) {
+			top = append(top, s)
+		}
+	}
+
+	sort.SliceStable(top, func(i, j int) bool {
+		a, b := top[i], top[j]
+		if a.Ante != b.Ante {
+			return a.Ante > b.Ante
+		}
+		if a.BestHand != b.BestHand {
+			return a.BestHand > b.BestHand
+		}
+		return a.Date.Before(b.Date)
+	})
+
+	if limit > 0 && len(top) > limit {
+		top = top[:limit]
+	}
+	return top
+}
+
+// SaveReplay writes the moves of a seeded run as a script that
+// `pkr run --script` can play again, and returns its path.
+func SaveReplay(dir string, score Score, moves []string) (string, error) {
+	if score.Seed == nil {
+		return "", errors.New("only seeded runs can be replayed")
+	}
+
+	replayDir := filepath.Join(dir, "replays")
+	if err := os.MkdirAll(replayDir, 0o750); err != nil {
+		return "", err
+	}
+	path := filepath.Join(replayDir, fmt.Sprintf("%s-%d.txt", score.Date.Format("20060102-150405"), *score.Seed))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Ante %d reached on %s\n", score.Ante, score.Date.Format(time.RFC3339))
+	deck := score.Deck
+	if score.DeckID != "" {
+		deck = score.DeckID
+	}
+	fmt.Fprintf(&b, "# pkr run --script %s --seed %d --deck %s --stake %s", path, *score.Seed, deck, score.Stake)
+	for _, joker := range score.Jokers {
+		fmt.Fprintf(&b, " --joker %s", joker)
+	}
+	if score.RulesFile != "" {
+		fmt.Fprintf(&b, " --rules %s", score.RulesFile)
+	}
+	b.WriteString("\n")
+	for _, move := range moves {
+		b.WriteString(move + "\n")
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0o600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,105 @@
+package profile
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAddScoreConcurrently(t *testing.T) {
+	dir := t.TempDir()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := AddScore(dir, Score{Date: time.Now(), Deck: "Standard", Stake: "White", Ante: i}); err != nil {
+				t.Errorf("AddScore() returned error: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	scores, err := LoadScores(dir)
+	if err != nil {
+		t.Fatalf("LoadScores() returned error: %v", err)
+	}
+	if len(scores) != 20 {
+		t.Errorf("LoadScores() returned %d scores, want 20", len(scores))
+	}
+}
+
+func TestLoadScoresMissing(t *testing.T) {
+	scores, err := LoadScores(t.TempDir())
+	if err != nil || len(scores) != 0 {
+		t.Errorf("LoadScores() = %v, %v, want no scores", scores, err)
+	}
+}
+
+func TestTopScores(t *testing.T) {
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	scores := []Score{
+		{Date: day, Deck: "Standard", Stake: "White", Ante: 2, BestHand: 100},
+		{Date: day.Add(time.Hour), Deck: "Red", Stake: "White", Ante: 3, BestHand: 50},
+		{Date: day.Add(2 * time.Hour), Deck: "Standard", Stake: "Gold", Ante: 2, BestHand: 200},
+		{Date: day.Add(3 * time.Hour), Deck: "Standard", Stake: "White", Ante: 2, BestHand: 100},
+		{Date: day.Add(4 * time.Hour), Deck: "Standard", Stake: "White", Rules: "Short Deck", Ante: 8, BestHand: 900},
+	}
+
+	top := TopScores(scores, ScoreFilter{Rules: "standard"}, 0)
+	want := []time.Time{day.Add(time.Hour), day.Add(2 * time.Hour), day, day.Add(3 * time.Hour)}
+	for i, s := range top {
+		if !s.Date.Equal(want[i]) {
+			t.Errorf("TopScores()[%d] = %+v, want the run of %v", i, s, want[i])
+		}
+	}
+
+	if len(top) != 4 {
+		t.Errorf("TopScores() with the standard rules returned %d scores, want 4", len(top))
+	}
+
+	if top := TopScores(scores, ScoreFilter{Deck: "standard", Stake: "white"}, 0); len(top) != 3 {
+		t.Errorf("TopScores() with a filter returned %d scores, want 3", len(top))
+	}
+	if top := TopScores(scores, ScoreFilter{Rules: "short deck"}, 0); len(top) != 1 || top[0].Ante != 8 {
+		t.Errorf("TopScores() with a rules filter = %+v, want the Short Deck run", top)
+	}
+	if top := TopScores(scores, ScoreFilter{Rules: "standard"}, 1); len(top) != 1 || top[0].Deck != "Red" {
+		t.Errorf("TopScores() with a limit = %+v, want the Red deck run", top)
+	}
+}
+
+func TestSaveReplay(t *testing.T) {
+	dir := t.TempDir()
+	seed := int64(42)
+	score := Score{Date: time.Now(), Seed: &seed, Deck: "Standard", Stake: "White", Jokers: []string{"hearts:lover"}, RulesFile: "short.yaml", Ante: 1}
+
+	path, err := SaveReplay(dir, score, []string{"play 1 2", "discard 3"})
+	if err != nil {
+		t.Fatalf("SaveReplay() returned error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "--seed 42 --deck Standard --stake White --joker hearts:lover --rules short.yaml\n") || !strings.HasSuffix(string(data), "play 1 2\ndiscard 3\n") {
+		t.Errorf("Replay content:\n%s", data)
+	}
+
+	score.Deck, score.DeckID = "Red Hearts", "hearts:red-hearts"
+	path, err = SaveReplay(dir, score, nil)
+	if err != nil {
+		t.Fatalf("SaveReplay() returned error: %v", err)
+	}
+	if data, err := os.ReadFile(path); err != nil || !strings.Contains(string(data), "--deck hearts:red-hearts ") {
+		t.Errorf("Replay of a pack deck:\n%s", data)
+	}
+
+	score.Seed = nil
+	if _, err := SaveReplay(dir, score, nil); err == nil {
+		t.Error("SaveReplay() of an unseeded run should return error")
+	}
+}
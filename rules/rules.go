@@ -0,0 +1,199 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/litencatt/pkr/entity"
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/viper"
+)
+
+// Key is the config key the rules are read from.
+const Key = "rules"
+
+// File is the rules section of a config file. Fields that are left out keep
+// their standard value.
+type File struct {
+	Name           string             `mapstructure:"name" yaml:"name"`
+	Deal           *int               `mapstructure:"deal" yaml:"deal"`
+	Hands          *int               `mapstructure:"hands" yaml:"hands"`
+	Discards       *int               `mapstructure:"discards" yaml:"discards"`
+	MaxSelectCards *int               `mapstructure:"max_select_cards" yaml:"max_select_cards"`
+	AnteAmounts    []int              `mapstructure:"ante_amounts" yaml:"ante_amounts"`
+	BlindMultis    []float64          `mapstructure:"blind_multis" yaml:"blind_multis"`
+	PokerHands     map[string][]Level `mapstructure:"poker_hands" yaml:"poker_hands"`
+	HandRanking    *Ranking           `mapstructure:"hand_ranking" yaml:"hand_ranking"`
+}
+
+// Ranking changes how hand types are made and ranked. It starts from a
+// built-in ranking, standard unless Base names another one.
+type Ranking struct {
+	Base              string   `mapstructure:"base" yaml:"base"`
+	Order             []string `mapstructure:"order" yaml:"order"`
+	LowestRank        *int     `mapstructure:"lowest_rank" yaml:"lowest_rank"`
+	FourCardFlushes   *bool    `mapstructure:"four_card_flushes" yaml:"four_card_flushes"`
+	FourCardStraights *bool    `mapstructure:"four_card_straights" yaml:"four_card_straights"`
+	GapStraights      *bool    `mapstructure:"gap_straights" yaml:"gap_straights"`
+}
+
+// Level is the chip and mult of a hand type at a level.
+type Level struct {
+	Level int `mapstructure:"level" yaml:"level"`
+	Chip  int `mapstructure:"chip" yaml:"chip"`
+	Mult  int `mapstructure:"mult" yaml:"mult"`
+}
+
+// Load returns the rules in the rules section of the config, or the
+// standard rules if there is none.
+func Load(v *viper.Viper) (entity.Rules, error) {
+	if !v.IsSet(Key) {
+		return entity.DefaultRules(), nil
+	}
+	return decode(v, Key, v.ConfigFileUsed())
+}
+
+// LoadFile returns the rules in a YAML or JSON file. The rules may be at the
+// top level or in a rules section.
+func LoadFile(path string) (entity.Rules, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return entity.Rules{}, fmt.Errorf("read rules %s: %w", path, err)
+	}
+	if v.IsSet(Key) {
+		return decode(v, Key, path)
+	}
+	return decode(v, "", path)
+}
+
+func decode(v *viper.Viper, key, source string) (entity.Rules, error) {
+	var f File
+	strict := func(c *mapstructure.DecoderConfig) { c.ErrorUnused = true }
+
+	var err error
+	if key == "" {
+		err = v.Unmarshal(&f, strict)
+	} else {
+		err = v.UnmarshalKey(key, &f, strict)
+	}
+	if err != nil {
+		return entity.Rules{}, fmt.Errorf("invalid rules in %s: %w", source, err)
+	}
+
+	rules, err := f.Rules()
+	if err != nil {
+		return entity.Rules{}, fmt.Errorf("invalid rules in %s:\n%w", source, err)
+	}
+	return rules, nil
+}
+
+// Rules applies the file to the standard rules and validates the result.
+func (f File) Rules() (entity.Rules, error) {
+	rules := entity.DefaultRules()
+	rules.Name = "Custom"
+	if f.Name != "" {
+		rules.Name = f.Name
+	}
+	if f.Deal != nil {
+		rules.Deal = *f.Deal
+	}
+	if f.Hands != nil {
+		rules.Hands = *f.Hands
+	}
+	if f.Discards != nil {
+		rules.Discards = *f.Discards
+	}
+	if f.MaxSelectCards != nil {
+		rules.MaxSelectCards = *f.MaxSelectCards
+	}
+	if f.AnteAmounts != nil {
+		rules.AnteAmounts = f.AnteAmounts
+	}
+	if f.BlindMultis != nil {
+		rules.BlindMultis = f.BlindMultis
+	}
+
+	for name, levels := range f.PokerHands {
+		handType, err := ParseHandType(name)
+		if err != nil {
+			return entity.Rules{}, fmt.Errorf("poker_hands: %w", err)
+		}
+		var table []entity.PokerHandLevel
+		for _, l := range levels {
+			if l.Level < 1 {
+				return entity.Rules{}, fmt.Errorf("poker_hands.%s: level must be at least 1, got %d", name, l.Level)
+			}
+			table = append(table, entity.PokerHandLevel{Level: l.Level, Chip: l.Chip, Mult: l.Mult})
+		}
+		for i := range rules.PokerHands.PokerHands {
+			if rules.PokerHands.PokerHands[i].HandType == handType {
+				rules.PokerHands.PokerHands[i].Level = table
+			}
+		}
+	}
+
+	if f.HandRanking != nil {
+		ranking, err := f.HandRanking.HandRanking()
+		if err != nil {
+			return entity.Rules{}, fmt.Errorf("hand_ranking: %w", err)
+		}
+		rules.PokerHands.Ranking = &ranking
+	}
+
+	if err := rules.Validate(); err != nil {
+		return entity.Rules{}, err
+	}
+	return rules, nil
+}
+
+// HandRanking applies the ranking section to its base ranking. The result
+// is validated with the rules.
+func (r Ranking) HandRanking() (entity.HandRanking, error) {
+	base := r.Base
+	if base == "" {
+		base = entity.StandardRanking().Name
+	}
+	ranking, err := entity.FindHandRanking(base)
+	if err != nil {
+		return entity.HandRanking{}, err
+	}
+
+	if r.Order != nil {
+		ranking.Order = nil
+		for _, name := range r.Order {
+			handType, err := ParseHandType(name)
+			if err != nil {
+				return entity.HandRanking{}, fmt.Errorf("order: %w", err)
+			}
+			ranking.Order = append(ranking.Order, handType)
+		}
+	}
+	if r.LowestRank != nil {
+		ranking.LowestRank = *r.LowestRank
+	}
+	if r.FourCardFlushes != nil {
+		ranking.FourCardFlushes = *r.FourCardFlushes
+	}
+	if r.FourCardStraights != nil {
+		ranking.FourCardStraights = *r.FourCardStraights
+	}
+	if r.GapStraights != nil {
+		ranking.GapStraights = *r.GapStraights
+	}
+	return ranking, nil
+}
+
+// ParseHandType matches a hand type name ignoring case, so that "flush",
+// "One Pair" and "one_pair" are all accepted.
+func ParseHandType(name string) (entity.HandType, error) {
+	normalized := strings.ReplaceAll(name, "_", " ")
+	var names []string
+	for _, handType := range entity.HandTypes() {
+		if strings.EqualFold(string(handType), normalized) {
+			return handType, nil
+		}
+		names = append(names, string(handType))
+	}
+	return "", fmt.Errorf("unknown hand type %q (available: %s)", name, strings.Join(names, ", "))
+}
@@ -0,0 +1,163 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/litencatt/pkr/entity"
+	"github.com/spf13/viper"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadFile(t *testing.T) {
+	path := writeFile(t, "quick.yaml", `
+name: Quick
+hands: 2
+max_select_cards: 3
+blind_multis: [1, 2]
+poker_hands:
+  flush:
+    - {level: 1, chip: 50, mult: 5}
+  one_pair:
+    - {level: 1, chip: 20, mult: 3}
+`)
+
+	rules, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() returned error: %v", err)
+	}
+
+	if rules.Name != "Quick" {
+		t.Errorf("Name = %q, want Quick", rules.Name)
+	}
+	if rules.Hands != 2 || rules.MaxSelectCards != 3 {
+		t.Errorf("Hands, MaxSelectCards = %d, %d, want 2, 3", rules.Hands, rules.MaxSelectCards)
+	}
+	// Left out fields keep the standard value
+	if rules.Deal != 8 || rules.Discards != 3 || len(rules.AnteAmounts) != len(entity.DefaultAnteAmounts()) {
+		t.Errorf("Standard values should be kept, got %+v", rules)
+	}
+	if len(rules.BlindMultis) != 2 {
+		t.Errorf("BlindMultis = %v, want [1 2]", rules.BlindMultis)
+	}
+	if chip, mult := rules.PokerHands.GetChipAndMult(entity.Flush, 1); chip != 50 || mult != 5 {
+		t.Errorf("Flush = %d x %d, want 50 x 5", chip, mult)
+	}
+	if chip, mult := rules.PokerHands.GetChipAndMult(entity.OnePair, 1); chip != 20 || mult != 3 {
+		t.Errorf("One Pair = %d x %d, want 20 x 3", chip, mult)
+	}
+	if chip, mult := rules.PokerHands.GetChipAndMult(entity.Straight, 1); chip != 30 || mult != 4 {
+		t.Errorf("Straight = %d x %d, want the standard 30 x 4", chip, mult)
+	}
+}
+
+func TestLoadFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"unknown key", "handz: 3\n", "handz"},
+		{"wrong type", "hands: many\n", "hands"},
+		{"invalid value", "hands: 0\ndiscards: -1\n", "hands must be at least 1"},
+		{"too many cards", "max_select_cards: 9\n", "max_select_cards must be between 1 and deal (8)"},
+		{"short antes", "ante_amounts: [100, 200]\n", "ante_amounts must have at least 8 entries"},
+		{"unknown hand", "poker_hands:\n  five_of_a_kind:\n    - {level: 1, chip: 1, mult: 1}\n", "unknown hand type"},
+		{"no level 1", "poker_hands:\n  flush:\n    - {level: 2, chip: 1, mult: 1}\n", "Flush level 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, "rules.yaml", tt.content)
+			_, err := LoadFile(path)
+			if err == nil {
+				t.Fatal("LoadFile() should return error")
+			}
+			if !strings.Contains(err.Error(), tt.want) || !strings.Contains(err.Error(), path) {
+				t.Errorf("LoadFile() error = %q, want it to mention %q and the file", err, tt.want)
+			}
+		})
+	}
+
+	// All problems are reported at once
+	_, err := LoadFile(writeFile(t, "rules.yaml", "hands: 0\ndiscards: -1\n"))
+	if err == nil || !strings.Contains(err.Error(), "discards must not be negative") {
+		t.Errorf("LoadFile() error = %v, want both problems", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	v := viper.New()
+	rules, err := Load(v)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if rules.Name != "Standard" {
+		t.Errorf("Load() without a rules section = %q, want Standard", rules.Name)
+	}
+
+	v.SetConfigFile(writeFile(t, "pkr.yaml", "rules:\n  discards: 5\n"))
+	if err := v.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+	rules, err = Load(v)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if rules.Name != "Custom" || rules.Discards != 5 {
+		t.Errorf("Load() = %q with %d discards, want Custom with 5", rules.Name, rules.Discards)
+	}
+}
+
+func TestLoadFileHandRanking(t *testing.T) {
+	path := writeFile(t, "short.yaml", `
+name: Short Deck
+hand_ranking:
+  base: short-deck
+  four_card_flushes: true
+`)
+	rules, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() returned error: %v", err)
+	}
+	ranking := rules.PokerHands.GetRanking()
+	if ranking.LowestRank != 6 || !ranking.FourCardFlushes || ranking.Score(entity.Flush) <= ranking.Score(entity.FullHouse) {
+		t.Errorf("Ranking = %+v, want short deck with four card flushes", ranking)
+	}
+
+	path = writeFile(t, "order.yaml", `
+hand_ranking:
+  order: [high_card, one_pair, two_pair, straight, three_of_a_kind, flush, full_house, four_of_a_kind, straight_flush, royal_flush]
+  gap_straights: true
+`)
+	rules, err = LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() returned error: %v", err)
+	}
+	ranking = rules.PokerHands.GetRanking()
+	if ranking.LowestRank != 2 || !ranking.GapStraights || ranking.Score(entity.Straight) != 4 {
+		t.Errorf("Ranking = %+v, want standard with Straight below Three of a Kind", ranking)
+	}
+
+	for content, want := range map[string]string{
+		"hand_ranking:\n  base: pineapple\n":           "unknown hand ranking",
+		"hand_ranking:\n  order: [high_card, flush]\n": "order is missing One Pair",
+		"hand_ranking:\n  order: [five_of_a_kind]\n":   "unknown hand type",
+		"hand_ranking:\n  lowest_rank: 12\n":           "lowest_rank must be between 2 and 10",
+		"hand_ranking:\n  four_card_pairs: true\n":     "four_card_pairs",
+	} {
+		if _, err := LoadFile(writeFile(t, "rules.yaml", content)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("LoadFile(%q) error = %v, want it to mention %q", content, err, want)
+		}
+	}
+}
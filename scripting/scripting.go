@@ -0,0 +1,343 @@
+// Package scripting runs Starlark scripts as hooks of jokers and boss
+// blinds. Scripts can only read the game state passed to the hooks and
+// change it through the ctx argument, and every hook call is limited in
+// steps and time so that a broken script cannot hang the game.
+//
+// A script defines any of these functions:
+//
+//	def on_card_scored(ctx, card, hand): ...
+//	def on_hand_scored(ctx, hand): ...
+//	def on_discard(ctx, cards): ...
+//	def on_round_end(ctx, round): ...
+//
+// The scoring hooks may call ctx.add_chips(n), ctx.add_mult(n) and
+// ctx.x_mult(x). Every hook may read the script state with
+// ctx.get(key, default), and on_discard and on_round_end may change it with
+// ctx.set(key, value).
+package scripting
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/litencatt/pkr/entity"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+const (
+	// DefaultMaxSteps is the default step limit of a hook call.
+	DefaultMaxSteps = 100000
+	// DefaultTimeout is the default time limit of a hook call.
+	DefaultTimeout = 100 * time.Millisecond
+)
+
+// Limits limit every hook call.
+type Limits struct {
+	MaxSteps uint64
+	Timeout  time.Duration
+}
+
+// DefaultLimits returns the default limits.
+func DefaultLimits() Limits {
+	return Limits{MaxSteps: DefaultMaxSteps, Timeout: DefaultTimeout}
+}
+
+const (
+	onCardScored = "on_card_scored"
+	onHandScored = "on_hand_scored"
+	onDiscard    = "on_discard"
+	onRoundEnd   = "on_round_end"
+)
+
+// hookParams is the number of parameters of each hook.
+var hookParams = map[string]int{
+	onCardScored: 3,
+	onHandScored: 2,
+	onDiscard:    2,
+	onRoundEnd:   2,
+}
+
+// Script is a loaded script. It implements entity.Hooks.
+type Script struct {
+	name   string
+	limits Limits
+	hooks  map[string]*starlark.Function
+	state  map[string]starlark.Value
+}
+
+var _ entity.Hooks = (*Script)(nil)
+
+// LoadFile loads a script file.
+func LoadFile(path string, limits Limits) (*Script, error) {
+	src, err := os.ReadFile(path) // #nosec G304 -- scripts are referenced by content packs
+	if err != nil {
+		return nil, err
+	}
+	return Load(path, src, limits)
+}
+
+// Load runs the top level of a script and returns its hooks. The script
+// must define at least one hook, and any other function whose name starts
+// with "on_" is reported as an unknown hook.
+func Load(name string, src []byte, limits Limits) (*Script, error) {
+	s := &Script{
+		name:   name,
+		limits: limits,
+		hooks:  make(map[string]*starlark.Function),
+		state:  make(map[string]starlark.Value),
+	}
+
+	var globals starlark.StringDict
+	err := s.run(func(thread *starlark.Thread) error {
+		var err error
+		globals, err = starlark.ExecFile(thread, name, src, nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, key := range globals.Keys() {
+		fn, ok := globals[key].(*starlark.Function)
+		if !ok || !strings.HasPrefix(key, "on_") {
+			continue
+		}
+		params, known := hookParams[key]
+		switch {
+		case !known:
+			errs = append(errs, fmt.Errorf("%s: unknown hook %s (available: on_card_scored, on_hand_scored, on_discard, on_round_end)", name, key))
+		case fn.NumParams() != params:
+			errs = append(errs, fmt.Errorf("%s: %s must take %d parameters, got %d", name, key, params, fn.NumParams()))
+		default:
+			s.hooks[key] = fn
+		}
+	}
+	if len(errs) == 0 && len(s.hooks) == 0 {
+		errs = append(errs, fmt.Errorf("%s: no hooks are defined", name))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Hooks returns the names of the defined hooks.
+func (s *Script) Hooks() []string {
+	var names []string
+	for _, name := range []string{onCardScored, onHandScored, onDiscard, onRoundEnd} {
+		if s.hooks[name] != nil {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func (s *Script) OnCardScored(card entity.Trump, stats *entity.PokerHandStats) error {
+	return s.call(onCardScored, &context{script: s, stats: stats}, cardValue(card), handValue(*stats))
+}
+
+func (s *Script) OnHandScored(stats *entity.PokerHandStats) error {
+	return s.call(onHandScored, &context{script: s, stats: stats}, handValue(*stats))
+}
+
+func (s *Script) OnDiscard(cards []entity.Trump) error {
+	return s.call(onDiscard, &context{script: s, writable: true}, cardsValue(cards))
+}
+
+func (s *Script) OnRoundEnd(stats entity.RoundStats) error {
+	round := starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+		"score":          starlark.MakeInt(stats.TotalScore),
+		"score_at_least": starlark.MakeInt(stats.ScoreAtLeast),
+		"hands":          starlark.MakeInt(stats.Hands),
+		"discards":       starlark.MakeInt(stats.Discards),
+		"cleared":        starlark.Bool(stats.TotalScore >= stats.ScoreAtLeast),
+	})
+	return s.call(onRoundEnd, &context{script: s, writable: true}, round)
+}
+
+func (s *Script) call(hook string, ctx *context, args ...starlark.Value) error {
+	fn := s.hooks[hook]
+	if fn == nil {
+		return nil
+	}
+	return s.run(func(thread *starlark.Thread) error {
+		_, err := starlark.Call(thread, fn, append(starlark.Tuple{ctx}, args...), nil)
+		return err
+	})
+}
+
+// run runs f in a new thread within the limits.
+func (s *Script) run(f func(thread *starlark.Thread) error) error {
+	thread := &starlark.Thread{
+		Name:  s.name,
+		Print: func(*starlark.Thread, string) {},
+	}
+	if s.limits.MaxSteps > 0 {
+		thread.SetMaxExecutionSteps(s.limits.MaxSteps)
+	}
+	if s.limits.Timeout > 0 {
+		timer := time.AfterFunc(s.limits.Timeout, func() {
+			thread.Cancel(fmt.Sprintf("timed out after %s", s.limits.Timeout))
+		})
+		defer timer.Stop()
+	}
+
+	err := f(thread)
+	var evalErr *starlark.EvalError
+	if errors.As(err, &evalErr) {
+		return errors.New(evalErr.Backtrace())
+	}
+	return err
+}
+
+// context is the ctx argument of the hooks.
+type context struct {
+	script *Script
+	// stats is the hand being scored in the scoring hooks.
+	stats *entity.PokerHandStats
+	// writable allows changing the script state.
+	writable bool
+}
+
+var contextAttrs = []string{"add_chips", "add_mult", "get", "set", "x_mult"}
+
+func (c *context) String() string        { return "ctx" }
+func (c *context) Type() string          { return "ctx" }
+func (c *context) Freeze()               {}
+func (c *context) Truth() starlark.Bool  { return true }
+func (c *context) Hash() (uint32, error) { return 0, errors.New("unhashable type: ctx") }
+func (c *context) AttrNames() []string   { return contextAttrs }
+
+func (c *context) Attr(name string) (starlark.Value, error) {
+	switch name {
+	case "add_chips":
+		return starlark.NewBuiltin(name, c.addChips), nil
+	case "add_mult":
+		return starlark.NewBuiltin(name, c.addMult), nil
+	case "x_mult":
+		return starlark.NewBuiltin(name, c.xMult), nil
+	case "get":
+		return starlark.NewBuiltin(name, c.get), nil
+	case "set":
+		return starlark.NewBuiltin(name, c.set), nil
+	}
+	return nil, nil
+}
+
+func (c *context) scoring(b *starlark.Builtin) error {
+	if c.stats == nil {
+		return fmt.Errorf("%s: only available in on_card_scored and on_hand_scored", b.Name())
+	}
+	return nil
+}
+
+func (c *context) addChips(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var n int
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 1, &n); err != nil {
+		return nil, err
+	}
+	if err := c.scoring(b); err != nil {
+		return nil, err
+	}
+	c.stats.Chip += n
+	c.stats.Score = c.stats.Chip * c.stats.Mult
+	return starlark.None, nil
+}
+
+func (c *context) addMult(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var n int
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 1, &n); err != nil {
+		return nil, err
+	}
+	if err := c.scoring(b); err != nil {
+		return nil, err
+	}
+	c.stats.Mult += n
+	c.stats.Score = c.stats.Chip * c.stats.Mult
+	return starlark.None, nil
+}
+
+func (c *context) xMult(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var x starlark.Value
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 1, &x); err != nil {
+		return nil, err
+	}
+	f, ok := starlark.AsFloat(x)
+	if !ok || f < 0 {
+		return nil, fmt.Errorf("%s: got %s, want a number not below 0", b.Name(), x)
+	}
+	if err := c.scoring(b); err != nil {
+		return nil, err
+	}
+	c.stats.Mult = int(math.Round(float64(c.stats.Mult) * f))
+	c.stats.Score = c.stats.Chip * c.stats.Mult
+	return starlark.None, nil
+}
+
+func (c *context) get(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var key string
+	var value starlark.Value = starlark.None
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 1, &key, &value); err != nil {
+		return nil, err
+	}
+	if v, ok := c.script.state[key]; ok {
+		return v, nil
+	}
+	return value, nil
+}
+
+func (c *context) set(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var key string
+	var value starlark.Value
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 2, &key, &value); err != nil {
+		return nil, err
+	}
+	if !c.writable {
+		return nil, fmt.Errorf("%s: only available in on_discard and on_round_end", b.Name())
+	}
+	switch value.(type) {
+	case starlark.NoneType, starlark.Bool, starlark.Int, starlark.Float, starlark.String:
+	default:
+		return nil, fmt.Errorf("%s: got %s, want None, bool, int, float or string", b.Name(), value.Type())
+	}
+	c.script.state[key] = value
+	return starlark.None, nil
+}
+
+func cardValue(card entity.Trump) starlark.Value {
+	rank := string(card.Rank)
+	if card.Rank == entity.Ten {
+		rank = "10"
+	}
+	return starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+		"suit":  starlark.String(card.Suit),
+		"rank":  starlark.String(rank),
+		"value": starlark.MakeInt(card.GetRankNumber()),
+	})
+}
+
+func cardsValue(cards []entity.Trump) starlark.Value {
+	values := make([]starlark.Value, len(cards))
+	for i, card := range cards {
+		values[i] = cardValue(card)
+	}
+	list := starlark.NewList(values)
+	list.Freeze()
+	return list
+}
+
+func handValue(stats entity.PokerHandStats) starlark.Value {
+	return starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+		"type":  starlark.String(stats.HandType),
+		"chips": starlark.MakeInt(stats.Chip),
+		"mult":  starlark.MakeInt(stats.Mult),
+		"score": starlark.MakeInt(stats.Score),
+		"cards": cardsValue(stats.ScoringCards),
+	})
+}
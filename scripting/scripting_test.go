@@ -0,0 +1,162 @@
+package scripting
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/litencatt/pkr/entity"
+)
+
+const discardScript = `
+def on_card_scored(ctx, card, hand):
+    if card.suit == "Hearts":
+        ctx.add_mult(2)
+
+def on_hand_scored(ctx, hand):
+    ctx.add_chips(ctx.get("discarded", 0))
+    if hand.type == "One Pair":
+        ctx.x_mult(1.5)
+
+def on_discard(ctx, cards):
+    ctx.set("discarded", ctx.get("discarded", 0) + len(cards))
+
+def on_round_end(ctx, round):
+    if round.cleared:
+        ctx.set("discarded", 0)
+`
+
+func pair() entity.PokerHandStats {
+	return entity.PokerHandStats{
+		HandType:     entity.OnePair,
+		ScoringCards: []entity.Trump{{Suit: entity.Hearts, Rank: entity.Ten}, {Suit: entity.Spades, Rank: entity.Ten}},
+		Chip:         30,
+		Mult:         2,
+		Score:        60,
+	}
+}
+
+func score(t *testing.T, s *Script) entity.PokerHandStats {
+	t.Helper()
+	stats := pair()
+	for _, card := range stats.ScoringCards {
+		if err := s.OnCardScored(card, &stats); err != nil {
+			t.Fatalf("OnCardScored() returned error: %v", err)
+		}
+	}
+	if err := s.OnHandScored(&stats); err != nil {
+		t.Fatalf("OnHandScored() returned error: %v", err)
+	}
+	return stats
+}
+
+func TestScript(t *testing.T) {
+	s, err := Load("discard.star", []byte(discardScript), DefaultLimits())
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if got := strings.Join(s.Hooks(), ","); got != "on_card_scored,on_hand_scored,on_discard,on_round_end" {
+		t.Errorf("Hooks() = %s", got)
+	}
+
+	stats := score(t, s)
+	if stats.Chip != 30 || stats.Mult != 6 || stats.Score != 180 {
+		t.Errorf("Chip, Mult, Score = %d, %d, %d, want 30, 6, 180", stats.Chip, stats.Mult, stats.Score)
+	}
+
+	cards := []entity.Trump{{Suit: entity.Clubs, Rank: entity.Two}, {Suit: entity.Clubs, Rank: entity.Three}}
+	if err := s.OnDiscard(cards); err != nil {
+		t.Fatalf("OnDiscard() returned error: %v", err)
+	}
+	if stats := score(t, s); stats.Chip != 32 {
+		t.Errorf("Chip = %d after discarding 2 cards, want 32", stats.Chip)
+	}
+
+	if err := s.OnRoundEnd(entity.RoundStats{TotalScore: 300, ScoreAtLeast: 300}); err != nil {
+		t.Fatalf("OnRoundEnd() returned error: %v", err)
+	}
+	if stats := score(t, s); stats.Chip != 30 {
+		t.Errorf("Chip = %d after the round end, want 30", stats.Chip)
+	}
+}
+
+func TestScriptRestrictions(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"set while scoring", "def on_hand_scored(ctx, hand):\n    ctx.set('x', 1)\n", "only available in on_discard and on_round_end"},
+		{"score on discard", "def on_discard(ctx, cards):\n    ctx.add_mult(1)\n", "only available in on_card_scored and on_hand_scored"},
+		{"state values", "def on_discard(ctx, cards):\n    ctx.set('x', [])\n", "want None, bool, int, float or string"},
+		{"read-only cards", "def on_discard(ctx, cards):\n    cards.append(1)\n", "frozen list"},
+		{"negative x_mult", "def on_hand_scored(ctx, hand):\n    ctx.x_mult(-1)\n", "want a number not below 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Load("test.star", []byte(tt.src), DefaultLimits())
+			if err != nil {
+				t.Fatalf("Load() returned error: %v", err)
+			}
+			stats := pair()
+			err = s.OnHandScored(&stats)
+			if err == nil {
+				err = s.OnDiscard(stats.ScoringCards)
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestScriptLimits(t *testing.T) {
+	src := []byte("def on_hand_scored(ctx, hand):\n    for i in range(100000000):\n        pass\n")
+
+	s, err := Load("loop.star", src, Limits{MaxSteps: 1000})
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	stats := pair()
+	if err := s.OnHandScored(&stats); err == nil || !strings.Contains(err.Error(), "too many steps") {
+		t.Errorf("OnHandScored() error = %v, want too many steps", err)
+	}
+
+	s, err = Load("loop.star", src, Limits{Timeout: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if err := s.OnHandScored(&stats); err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("OnHandScored() error = %v, want timed out", err)
+	}
+
+	// The top level of a script is limited too
+	_, err = Load("top.star", []byte("[i for i in range(100000000)]\ndef on_discard(ctx, cards):\n    pass\n"), Limits{MaxSteps: 1000})
+	if err == nil || !strings.Contains(err.Error(), "too many steps") {
+		t.Errorf("Load() error = %v, want too many steps", err)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"syntax", "def on_discard(ctx, cards)\n", "got newline"},
+		{"no hooks", "x = 1\n", "no hooks are defined"},
+		{"unknown hook", "def on_play(ctx, hand):\n    pass\n", "unknown hook on_play"},
+		{"parameters", "def on_discard(ctx):\n    pass\n", "on_discard must take 2 parameters, got 1"},
+		{"load", "load('other.star', 'x')\n", "load not implemented"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load("test.star", []byte(tt.src), DefaultLimits())
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
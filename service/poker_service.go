@@ -1,6 +1,8 @@
 package service
 
 import (
+	"math/rand"
+
 	"github.com/litencatt/pkr/entity"
 )
 
@@ -9,24 +11,47 @@ type PokerService interface {
 	StartRound() error
 	GetRounds() int
 	IsRoundWin() bool
+	IsRoundLost() bool
+	IsRunWon() bool
 	NextRound() error
 	GetRoundStats() *entity.RoundStats
 
 	SelectCards([]string) error
 	DrawCard(int) ([]entity.Trump, error)
+	PreviewHand([]string) (entity.PokerHandStats, error)
+	ScoreHand([]entity.Trump) (entity.PokerHandStats, error)
 	PlayHand() (entity.PokerHandStats, error)
 	DiscardHand() error
 	CancelHand() error
 
+	GetAnte() int
 	GetCurrentAnteAmount() int
 	GetCurrentBlindMulti() float64
 	GetNextDrawNum() int
 	GetChipAndMult(entity.HandType, int) (int, int)
+	GetHandCards() []entity.Trump
 	GetHandCardString() []string
+	GetRemainCards() []entity.Trump
 	GetRemainCardString() []string
+	GetDeckRemaining() int
+	GetDeckCards() []entity.Trump
+	GetPokerHands() *entity.PokerHands
 	GetEnableActions() []string
+	GetRulesName() string
+	GetMaxSelectCards() int
+	GetDeckName() string
+	GetDeckID() string
+	GetJokers() []entity.Joker
+	GetStartingJokers() []entity.Joker
+	GetChallenge() *entity.Challenge
+	GetBossBlind() *entity.BossBlind
+	GetStakeName() string
+	GetSeed() (int64, bool)
+	GetRunStats() entity.RunStats
 
 	SetAction(string)
+	UseHints()
+	IsHintsUsed() bool
 }
 
 type pokerService struct {
@@ -36,7 +61,27 @@ type pokerService struct {
 }
 
 func NewPokerService(config PokerServiceConfig) PokerService {
-	runInfo := entity.NewRunInfo()
+	rules := entity.DefaultRules()
+	if config.Rules != nil {
+		rules = *config.Rules
+	}
+	if config.Challenge != nil && config.Challenge.Rules != nil {
+		rules = *config.Challenge.Rules
+	}
+	runInfo := entity.NewRunInfoWithRules(rules)
+	if config.Seed != nil {
+		runInfo.Rand = rand.New(rand.NewSource(*config.Seed)) // #nosec G404 -- seeded games must be reproducible
+	}
+	if config.Challenge != nil {
+		runInfo.UseChallenge(*config.Challenge)
+	} else if config.Deck != nil {
+		runInfo.UseDeck(*config.Deck)
+	}
+	if config.Stake != nil {
+		runInfo.UseStake(*config.Stake)
+	}
+	runInfo.Jokers = append(runInfo.Jokers, config.Jokers...)
+	runInfo.BossBlinds = config.BossBlinds
 	round := entity.NewPokerRound(
 		runInfo.Deck,
 		runInfo.DefaultHands,
@@ -53,6 +98,20 @@ func NewPokerService(config PokerServiceConfig) PokerService {
 
 type PokerServiceConfig struct {
 	DebugMode bool
+	// Seed makes the deck order reproducible when set.
+	Seed *int64
+	// Deck replaces the standard deck when set.
+	Deck *entity.StartingDeck
+	// Stake replaces the White stake when set.
+	Stake *entity.Stake
+	// Rules replaces the standard rules when set. They must be valid.
+	Rules *entity.Rules
+	// Challenge replaces Deck and Rules with its own when set.
+	Challenge *entity.Challenge
+	// Jokers are added to the jokers of the deck.
+	Jokers []entity.Joker
+	// BossBlinds are played as the last blind of every ante.
+	BossBlinds []entity.BossBlind
 }
 
 func (s *pokerService) GetNextDrawNum() int {
@@ -77,15 +136,16 @@ func (s *pokerService) IsStartRound() bool {
 
 func (s *pokerService) StartRound() error {
 	s.runInfo.UnsetStartNext()
+	s.runInfo.ChooseBoss()
 
-	scoreAtLeast := int(float64(s.GetCurrentAnteAmount()) * s.GetCurrentBlindMulti())
+	scoreAtLeast := s.runInfo.ScoreAtLeast()
 	s.round = entity.NewPokerRound(
 		s.runInfo.Deck,
-		s.runInfo.DefaultHands,
-		s.runInfo.DefaultDiscards,
+		s.runInfo.RoundHands(),
+		s.runInfo.RoundDiscards(),
 		scoreAtLeast,
 	)
-	s.round.Deck.Shuffle()
+	s.runInfo.ShuffleDeck(s.round.Deck)
 
 	return nil
 }
@@ -95,15 +155,19 @@ func (s *pokerService) DrawCard(num int) ([]entity.Trump, error) {
 	return cards, nil
 }
 
+func (s *pokerService) GetAnte() int {
+	return s.runInfo.Ante()
+}
+
 func (s *pokerService) GetCurrentAnteAmount() int {
-	return entity.AnteAmounts[s.runInfo.AnteIndex]
+	return s.runInfo.AnteAmount()
 }
 
 func (s *pokerService) NextRound() error {
 	if err := s.runInfo.NextRound(); err != nil {
 		return err
 	}
-	scoreAtLeast := int(float64(s.GetCurrentAnteAmount()) * s.GetCurrentBlindMulti())
+	scoreAtLeast := s.runInfo.ScoreAtLeast()
 	s.round = entity.NewPokerRound(
 		s.runInfo.Deck,
 		s.runInfo.DefaultHands,
@@ -114,7 +178,7 @@ func (s *pokerService) NextRound() error {
 }
 
 func (s *pokerService) GetCurrentBlindMulti() float64 {
-	return entity.BlindMultis[s.runInfo.BlindIndex]
+	return s.runInfo.BlindMultis[s.runInfo.BlindIndex]
 }
 
 func (s *pokerService) GetEnableActions() []string {
@@ -136,6 +200,12 @@ func (s *pokerService) SelectCards(cards []string) error {
 func (s *pokerService) DiscardHand() error {
 	s.round.Stats.Discards--
 
+	if err := s.runInfo.Discard(s.round.SelectedCards); err != nil {
+		return err
+	}
+	if s.IsRoundLost() {
+		return s.runInfo.EndRound(s.round.Stats)
+	}
 	return nil
 }
 
@@ -145,39 +215,129 @@ func (s *pokerService) CancelHand() error {
 	return nil
 }
 
-func (s *pokerService) PlayHand() (entity.PokerHandStats, error) {
-	s.round.Stats.Hands--
+// PreviewHand returns the stats the cards would score if played, without
+// changing the round.
+func (s *pokerService) PreviewHand(cards []string) (entity.PokerHandStats, error) {
+	selected, err := s.round.FindHandCards(cards)
+	if err != nil {
+		return entity.PokerHandStats{}, err
+	}
 
-	round := s.round
+	return s.runInfo.ScoreHand(selected)
+}
 
-	// get hand type and base chip and mult
-	handType := round.PlayHand()
-	chip, mult := s.GetChipAndMult(handType, 1)
+// ScoreHand returns the stats of the cards if they were played in the
+// current round, without playing them.
+func (s *pokerService) ScoreHand(cards []entity.Trump) (entity.PokerHandStats, error) {
+	return s.runInfo.ScoreHand(cards)
+}
 
-	// get card rank and add to chip
-	handsRankTotal := round.GetSelectCardsRankTotal()
-	chip += handsRankTotal
-	score := chip * mult
-	round.Stats.TotalScore += score
+func (s *pokerService) PlayHand() (entity.PokerHandStats, error) {
+	s.round.Stats.Hands--
 
-	stats := entity.PokerHandStats{
-		HandType: handType,
-		Chip:     chip,
-		Mult:     mult,
-		Score:    score,
+	// get hand type, chip and mult of the selected cards
+	stats, err := s.runInfo.ScoreHand(s.round.SelectedCards)
+	if err != nil {
+		return stats, err
 	}
+	s.round.Stats.TotalScore += stats.Score
+	s.runInfo.RecordPlay(stats)
 
+	if s.round.IsWin() || s.IsRoundLost() {
+		if err := s.runInfo.EndRound(s.round.Stats); err != nil {
+			return stats, err
+		}
+	}
 	return stats, nil
 }
 
+func (s *pokerService) GetHandCards() []entity.Trump {
+	return s.round.HandCards
+}
+
 func (s *pokerService) GetHandCardString() []string {
 	return s.round.HandCardString()
 }
 
+func (s *pokerService) GetRemainCards() []entity.Trump {
+	return append([]entity.Trump(nil), s.round.RemainCards...)
+}
+
 func (s *pokerService) GetRemainCardString() []string {
 	return s.round.RemainCardString()
 }
 
+func (s *pokerService) GetDeckRemaining() int {
+	return s.round.Deck.Len()
+}
+
+func (s *pokerService) GetDeckCards() []entity.Trump {
+	return append([]entity.Trump(nil), s.round.Deck...)
+}
+
+func (s *pokerService) GetPokerHands() *entity.PokerHands {
+	return s.runInfo.PokerHands
+}
+
+func (s *pokerService) GetRulesName() string {
+	return s.runInfo.RulesName
+}
+
+func (s *pokerService) GetMaxSelectCards() int {
+	return s.runInfo.MaxSelectCards
+}
+
+func (s *pokerService) GetDeckName() string {
+	return s.runInfo.DeckName
+}
+
+// GetDeckID returns the ID of a pack deck, or "" for a built-in deck.
+func (s *pokerService) GetDeckID() string {
+	return s.runInfo.DeckID
+}
+
+func (s *pokerService) GetJokers() []entity.Joker {
+	return append([]entity.Joker(nil), s.runInfo.Jokers...)
+}
+
+// GetStartingJokers returns the jokers the run was started with on top of
+// the jokers of its deck or challenge.
+func (s *pokerService) GetStartingJokers() []entity.Joker {
+	return append([]entity.Joker(nil), s.config.Jokers...)
+}
+
+// GetChallenge returns the challenge being played, or nil.
+func (s *pokerService) GetChallenge() *entity.Challenge {
+	return s.runInfo.Challenge
+}
+
+// GetBossBlind returns the boss blind of the current round, or nil.
+func (s *pokerService) GetBossBlind() *entity.BossBlind {
+	return s.runInfo.Boss
+}
+
+func (s *pokerService) GetStakeName() string {
+	return s.runInfo.Stake.Name
+}
+
+// GetSeed returns the seed of the run, if it was started with one.
+func (s *pokerService) GetSeed() (int64, bool) {
+	if s.config.Seed == nil {
+		return 0, false
+	}
+	return *s.config.Seed, true
+}
+
+// GetRunStats returns a copy of the stats accumulated over the run.
+func (s *pokerService) GetRunStats() entity.RunStats {
+	stats := s.runInfo.Stats
+	stats.HandTypes = make(map[entity.HandType]int, len(s.runInfo.Stats.HandTypes))
+	for handType, n := range s.runInfo.Stats.HandTypes {
+		stats.HandTypes[handType] = n
+	}
+	return stats
+}
+
 func (s *pokerService) GetRoundStats() *entity.RoundStats {
 	return s.round.GetRoundStats()
 }
@@ -186,10 +346,28 @@ func (s *pokerService) SetAction(action string) {
 	s.round.BeforeSelectAction = action
 }
 
+func (s *pokerService) UseHints() {
+	s.runInfo.HintsUsed = true
+}
+
+func (s *pokerService) IsHintsUsed() bool {
+	return s.runInfo.HintsUsed
+}
+
 func (s *pokerService) IsRoundWin() bool {
 	return s.round.IsWin()
 }
 
+// IsRoundLost reports whether the round is over without being won, because
+// no hands or no cards are left.
+func (s *pokerService) IsRoundLost() bool {
+	return !s.round.IsWin() && (s.round.Stats.Hands == 0 || s.round.IsOutOfCards())
+}
+
+func (s *pokerService) IsRunWon() bool {
+	return s.runInfo.IsWon()
+}
+
 func (s *pokerService) GetRounds() int {
 	return s.runInfo.Rounds
 }
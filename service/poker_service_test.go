@@ -243,7 +243,7 @@ func TestGetCurrentAnteAmount(t *testing.T) {
 	ps := service.(*pokerService)
 
 	ante := service.GetCurrentAnteAmount()
-	expectedAnte := entity.AnteAmounts[ps.runInfo.AnteIndex]
+	expectedAnte := ps.runInfo.AnteAmounts[ps.runInfo.AnteIndex]
 
 	if ante != expectedAnte {
 		t.Errorf("GetCurrentAnteAmount() = %d, want %d", ante, expectedAnte)
@@ -255,7 +255,7 @@ func TestGetCurrentBlindMulti(t *testing.T) {
 	ps := service.(*pokerService)
 
 	blind := service.GetCurrentBlindMulti()
-	expectedBlind := entity.BlindMultis[ps.runInfo.BlindIndex]
+	expectedBlind := ps.runInfo.BlindMultis[ps.runInfo.BlindIndex]
 
 	if blind != expectedBlind {
 		t.Errorf("GetCurrentBlindMulti() = %f, want %f", blind, expectedBlind)
@@ -363,3 +363,222 @@ func TestSetAction(t *testing.T) {
 		}
 	}
 }
+
+func TestNewPokerServiceWithSeed(t *testing.T) {
+	seed := int64(42)
+	service1 := NewPokerService(PokerServiceConfig{Seed: &seed})
+	service2 := NewPokerService(PokerServiceConfig{Seed: &seed})
+
+	for _, s := range []PokerService{service1, service2} {
+		if err := s.StartRound(); err != nil {
+			t.Fatalf("StartRound() returned error: %v", err)
+		}
+	}
+
+	cards1, _ := service1.DrawCard(8)
+	cards2, _ := service2.DrawCard(8)
+	for i := range cards1 {
+		if cards1[i] != cards2[i] {
+			t.Errorf("Seeded services drew different cards at %d: %s != %s", i, cards1[i], cards2[i])
+		}
+	}
+}
+
+func TestUseHints(t *testing.T) {
+	service := NewPokerService(PokerServiceConfig{})
+
+	if service.IsHintsUsed() {
+		t.Error("IsHintsUsed() should be false initially")
+	}
+
+	service.UseHints()
+	if !service.IsHintsUsed() {
+		t.Error("IsHintsUsed() should be true after UseHints()")
+	}
+}
+
+func TestPreviewHand(t *testing.T) {
+	service := NewPokerService(PokerServiceConfig{})
+	ps := service.(*pokerService)
+
+	ps.round.Stats.Hands = 4
+	ps.round.HandCards = []entity.Trump{
+		{Suit: entity.Spades, Rank: entity.Ace},
+		{Suit: entity.Hearts, Rank: entity.Ace},
+		{Suit: entity.Diamonds, Rank: entity.Queen},
+	}
+
+	preview, err := service.PreviewHand([]string{"A of Spades", "A of Hearts", "Q of Diamonds"})
+	if err != nil {
+		t.Fatalf("PreviewHand() returned error: %v", err)
+	}
+
+	if preview.HandType != entity.OnePair {
+		t.Errorf("PreviewHand() HandType = %s, want %s", preview.HandType, entity.OnePair)
+	}
+	if len(preview.ScoringCards) != 2 {
+		t.Errorf("PreviewHand() returned %d scoring cards, want 2", len(preview.ScoringCards))
+	}
+	if preview.Score != (10+14+14+12)*2 {
+		t.Errorf("PreviewHand() Score = %d, want %d", preview.Score, (10+14+14+12)*2)
+	}
+
+	// Preview must not change the round
+	if ps.round.Stats.Hands != 4 || ps.round.Stats.TotalScore != 0 {
+		t.Errorf("PreviewHand() changed stats to %+v", ps.round.Stats)
+	}
+	if len(ps.round.SelectedCards) != 0 {
+		t.Error("PreviewHand() should not select cards")
+	}
+
+	if _, err := service.PreviewHand([]string{"K of Clubs"}); err == nil {
+		t.Error("PreviewHand() with a card not in hand should return error")
+	}
+}
+
+func TestGetRunStats(t *testing.T) {
+	service := NewPokerService(PokerServiceConfig{})
+	ps := service.(*pokerService)
+
+	ps.round.HandCards = []entity.Trump{
+		{Suit: entity.Spades, Rank: entity.Ace},
+		{Suit: entity.Hearts, Rank: entity.Ace},
+		{Suit: entity.Diamonds, Rank: entity.Queen},
+		{Suit: entity.Clubs, Rank: entity.Two},
+	}
+
+	_ = service.SelectCards([]string{"Q of Diamonds", "2 of Clubs"})
+	_ = service.DiscardHand()
+	_ = service.SelectCards([]string{"A of Spades", "A of Hearts"})
+	played, _ := service.PlayHand()
+
+	stats := service.GetRunStats()
+	if stats.CardsDiscarded != 2 {
+		t.Errorf("CardsDiscarded = %d, want 2", stats.CardsDiscarded)
+	}
+	if stats.HandTypes[entity.OnePair] != 1 {
+		t.Errorf("HandTypes = %v, want 1 One Pair", stats.HandTypes)
+	}
+	if stats.BestHandScore != played.Score {
+		t.Errorf("BestHandScore = %d, want %d", stats.BestHandScore, played.Score)
+	}
+
+	// The returned stats are a copy
+	stats.HandTypes[entity.Flush] = 1
+	if service.GetRunStats().HandTypes[entity.Flush] != 0 {
+		t.Error("GetRunStats() should return a copy")
+	}
+}
+
+func TestNewPokerServiceWithDeck(t *testing.T) {
+	deck, _ := entity.FindStartingDeck("Blue")
+	service := NewPokerService(PokerServiceConfig{Deck: &deck})
+	_ = service.StartRound()
+
+	if service.GetDeckName() != "Blue" {
+		t.Errorf("GetDeckName() = %q, want Blue", service.GetDeckName())
+	}
+	if service.GetRoundStats().Hands != 5 {
+		t.Errorf("Hands = %d, want 5", service.GetRoundStats().Hands)
+	}
+}
+
+func TestNewPokerServiceWithRules(t *testing.T) {
+	rules := entity.DefaultRules()
+	rules.Name = "Quick"
+	rules.Hands = 2
+	rules.MaxSelectCards = 3
+	rules.AnteAmounts = []int{100, 200, 300, 400, 500, 600, 700, 800}
+	service := NewPokerService(PokerServiceConfig{Rules: &rules})
+	_ = service.StartRound()
+
+	if service.GetRulesName() != "Quick" || service.GetMaxSelectCards() != 3 {
+		t.Errorf("GetRulesName(), GetMaxSelectCards() = %q, %d, want Quick, 3", service.GetRulesName(), service.GetMaxSelectCards())
+	}
+	if service.GetRoundStats().Hands != 2 || service.GetRoundStats().ScoreAtLeast != 100 {
+		t.Errorf("RoundStats = %+v, want 2 hands and 100 to clear", *service.GetRoundStats())
+	}
+
+	// Antes beyond the table reuse the last amount
+	ps := service.(*pokerService)
+	ps.runInfo.AnteIndex = 10
+	if service.GetCurrentAnteAmount() != 800 {
+		t.Errorf("GetCurrentAnteAmount() = %d, want 800", service.GetCurrentAnteAmount())
+	}
+}
+
+func TestNewPokerServiceWithJokers(t *testing.T) {
+	joker := entity.Joker{Name: "Plus", Effects: []entity.Effect{{Mult: 4}}}
+	service := NewPokerService(PokerServiceConfig{Jokers: []entity.Joker{joker}})
+	_ = service.StartRound()
+	if service.GetBossBlind() != nil {
+		t.Errorf("GetBossBlind() = %v, want nil without boss blinds", service.GetBossBlind())
+	}
+
+	cards, _ := service.DrawCard(service.GetNextDrawNum())
+	card := cards[0]
+	stats, err := service.PreviewHand([]string{card.String()})
+	if err != nil {
+		t.Fatalf("PreviewHand() returned error: %v", err)
+	}
+	if stats.HandType != entity.HighCard || stats.Mult != 5 {
+		t.Errorf("HandType, Mult = %s, %d, want High Card, 5", stats.HandType, stats.Mult)
+	}
+	if len(service.GetJokers()) != 1 {
+		t.Errorf("len(GetJokers()) = %d, want 1", len(service.GetJokers()))
+	}
+}
+
+func TestNewPokerServiceWithChallenge(t *testing.T) {
+	rules := entity.DefaultRules()
+	rules.Name = "Mindful"
+	rules.Discards = 0
+	deck, _ := entity.FindStartingDeck("Abandoned")
+	service := NewPokerService(PokerServiceConfig{
+		Deck:      &deck,
+		Challenge: &entity.Challenge{ID: "mindful", Name: "Mindful", Deck: entity.StandardDeck(), Rules: &rules},
+	})
+	_ = service.StartRound()
+
+	if service.GetChallenge() == nil || service.GetChallenge().ID != "mindful" {
+		t.Errorf("GetChallenge() = %v, want mindful", service.GetChallenge())
+	}
+	// The challenge replaces the deck and the rules
+	if service.GetDeckName() != "Standard" || service.GetRulesName() != "Mindful" {
+		t.Errorf("GetDeckName(), GetRulesName() = %q, %q, want Standard, Mindful", service.GetDeckName(), service.GetRulesName())
+	}
+	if service.GetRoundStats().Discards != 0 {
+		t.Errorf("Discards = %d, want 0", service.GetRoundStats().Discards)
+	}
+}
+
+func TestIsRoundLostOutOfCards(t *testing.T) {
+	var cards []entity.Trump
+	for _, rank := range []entity.Rank{entity.Two, entity.Three, entity.Five, entity.Seven, entity.Nine} {
+		cards = append(cards, entity.Trump{Suit: entity.Clubs, Rank: rank}, entity.Trump{Suit: entity.Hearts, Rank: rank})
+	}
+	deck := entity.StartingDeck{Name: "Tiny", Cards: cards}
+	service := NewPokerService(PokerServiceConfig{Deck: &deck})
+	_ = service.StartRound()
+
+	// 10 cards are played in two hands of 5
+	for i := 0; i < 2; i++ {
+		_, _ = service.DrawCard(service.GetNextDrawNum())
+		if service.IsRoundLost() {
+			t.Fatalf("IsRoundLost() = true before play %d", i+1)
+		}
+		var selected []string
+		for _, card := range service.GetHandCards()[:5] {
+			selected = append(selected, card.String())
+		}
+		_ = service.SelectCards(selected)
+		service.SetAction("Play")
+		if _, err := service.PlayHand(); err != nil {
+			t.Fatalf("PlayHand() returned error: %v", err)
+		}
+	}
+
+	if service.GetRoundStats().Hands == 0 || !service.IsRoundLost() {
+		t.Errorf("IsRoundLost() = %v with %d hands left, want true when out of cards", service.IsRoundLost(), service.GetRoundStats().Hands)
+	}
+}
@@ -0,0 +1,159 @@
+package sim
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/litencatt/pkr/bot"
+	"github.com/litencatt/pkr/entity"
+	"github.com/litencatt/pkr/service"
+)
+
+// Config is the configuration of a simulation.
+type Config struct {
+	Runs    int
+	Workers int
+	Agent   string
+	// Seed is the base seed. Run i is played with Seed+i, so the report
+	// does not depend on the number of workers.
+	Seed int64
+	// Rules replaces the standard rules when set.
+	Rules *entity.Rules
+}
+
+// HandTypeStats is how often a hand type was played and what it scored.
+type HandTypeStats struct {
+	Played     int
+	TotalScore int
+}
+
+// AverageScore returns the average score of the hand type.
+func (h HandTypeStats) AverageScore() float64 {
+	if h.Played == 0 {
+		return 0
+	}
+	return float64(h.TotalScore) / float64(h.Played)
+}
+
+// Report is the aggregated result of a simulation.
+type Report struct {
+	Runs        int
+	Wins        int
+	HandsPlayed int
+	// Antes is the number of runs that ended at each ante.
+	Antes     map[int]int
+	HandTypes map[entity.HandType]HandTypeStats
+}
+
+// WinRate returns the ratio of won runs.
+func (r *Report) WinRate() float64 {
+	if r.Runs == 0 {
+		return 0
+	}
+	return float64(r.Wins) / float64(r.Runs)
+}
+
+// SortedAntes returns the antes reached in ascending order.
+func (r *Report) SortedAntes() []int {
+	var antes []int
+	for ante := range r.Antes {
+		antes = append(antes, ante)
+	}
+	sort.Ints(antes)
+	return antes
+}
+
+// SortedHandTypes returns the played hand types from weakest to strongest.
+func (r *Report) SortedHandTypes() []entity.HandType {
+	var handTypes []entity.HandType
+	for handType := range r.HandTypes {
+		handTypes = append(handTypes, handType)
+	}
+	sort.Slice(handTypes, func(i, j int) bool {
+		return entity.GetScore(handTypes[i]) < entity.GetScore(handTypes[j])
+	})
+	return handTypes
+}
+
+func (r *Report) add(result *bot.Result) {
+	r.Runs++
+	if result.Won {
+		r.Wins++
+	}
+	r.Antes[result.Ante]++
+
+	for _, hand := range result.PlayedHands() {
+		r.HandsPlayed++
+		stats := r.HandTypes[hand.HandType]
+		stats.Played++
+		stats.TotalScore += hand.Score
+		r.HandTypes[hand.HandType] = stats
+	}
+}
+
+// Run plays cfg.Runs seeded games concurrently and aggregates the results.
+func Run(cfg Config) (*Report, error) {
+	if cfg.Runs <= 0 {
+		return nil, errors.New("runs must be positive")
+	}
+	if cfg.Workers <= 0 {
+		return nil, errors.New("workers must be positive")
+	}
+	if _, err := bot.NewAgent(cfg.Agent, cfg.Seed); err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		Antes:     make(map[int]int),
+		HandTypes: make(map[entity.HandType]HandTypeStats),
+	}
+
+	jobs := make(chan int)
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	for w := 0; w < cfg.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result, err := playRun(cfg, cfg.Seed+int64(i))
+
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = fmt.Errorf("run %d: %w", i, err)
+					}
+				} else {
+					report.add(result)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := 0; i < cfg.Runs; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return report, nil
+}
+
+func playRun(cfg Config, seed int64) (*bot.Result, error) {
+	agent, err := bot.NewAgent(cfg.Agent, seed)
+	if err != nil {
+		return nil, err
+	}
+
+	svc := service.NewPokerService(service.PokerServiceConfig{Seed: &seed, Rules: cfg.Rules})
+	return bot.Run(svc, agent)
+}
@@ -0,0 +1,87 @@
+package sim
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/litencatt/pkr/entity"
+)
+
+func TestRunDeterministic(t *testing.T) {
+	for _, agent := range []string{"greedy", "random"} {
+		t.Run(agent, func(t *testing.T) {
+			single, err := Run(Config{Runs: 50, Workers: 1, Agent: agent, Seed: 7})
+			if err != nil {
+				t.Fatalf("Run() returned error: %v", err)
+			}
+			parallel, err := Run(Config{Runs: 50, Workers: 8, Agent: agent, Seed: 7})
+			if err != nil {
+				t.Fatalf("Run() returned error: %v", err)
+			}
+
+			if !reflect.DeepEqual(single, parallel) {
+				t.Errorf("Reports differ between 1 and 8 workers:\n%+v\n%+v", single, parallel)
+			}
+		})
+	}
+}
+
+func TestRunReport(t *testing.T) {
+	report, err := Run(Config{Runs: 20, Workers: 4, Agent: "greedy", Seed: 1})
+	if err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+
+	if report.Runs != 20 {
+		t.Errorf("Report.Runs = %d, want 20", report.Runs)
+	}
+
+	antes := 0
+	for _, count := range report.Antes {
+		antes += count
+	}
+	if antes != 20 {
+		t.Errorf("Report.Antes counts %d runs, want 20", antes)
+	}
+
+	played := 0
+	for _, stats := range report.HandTypes {
+		played += stats.Played
+	}
+	if played != report.HandsPlayed {
+		t.Errorf("Report.HandTypes counts %d hands, want %d", played, report.HandsPlayed)
+	}
+}
+
+func TestRunReportWon(t *testing.T) {
+	// Every hand clears the round, so every run is won
+	rules := entity.DefaultRules()
+	rules.AnteAmounts = []int{1, 1, 1, 1, 1, 1, 1, 1}
+	report, err := Run(Config{Runs: 4, Workers: 2, Agent: "greedy", Seed: 1, Rules: &rules})
+	if err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+
+	if report.Wins != 4 || len(report.Antes) != 1 || report.Antes[entity.WinAnte] != 4 {
+		t.Errorf("Report.Wins, Antes = %d, %v, want 4 runs at ante %d", report.Wins, report.Antes, entity.WinAnte)
+	}
+}
+
+func TestRunInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{"no runs", Config{Runs: 0, Workers: 1, Agent: "greedy"}},
+		{"no workers", Config{Runs: 1, Workers: 0, Agent: "greedy"}},
+		{"unknown agent", Config{Runs: 1, Workers: 1, Agent: "unknown"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Run(tt.cfg); err == nil {
+				t.Error("Run() should return error")
+			}
+		})
+	}
+}
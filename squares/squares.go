@@ -0,0 +1,209 @@
+// Package squares plays Poker Squares: 25 cards are placed one at a time on
+// a 5×5 grid, and every row and column scores as a poker hand.
+package squares
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/litencatt/pkr/entity"
+)
+
+// Size is the number of rows and columns of the grid.
+const Size = 5
+
+var (
+	// ErrOccupied is returned when placing a card on a filled cell.
+	ErrOccupied = errors.New("cell is already filled")
+	// ErrGridFull is returned when placing a card after the grid is full.
+	ErrGridFull = errors.New("grid is full")
+)
+
+// Scoring is the points every poker hand scores.
+type Scoring struct {
+	ID     string
+	Name   string
+	Points map[entity.HandType]int
+}
+
+// Scorings returns the built-in scoring tables.
+func Scorings() []Scoring {
+	return []Scoring{
+		{
+			ID:   "american",
+			Name: "American",
+			Points: map[entity.HandType]int{
+				entity.RoyalFlush: 100, entity.StraightFlush: 75, entity.FourOfAKind: 50, entity.FullHouse: 25,
+				entity.Flush: 20, entity.Straight: 15, entity.ThreeOfAKind: 10, entity.TwoPair: 5, entity.OnePair: 2,
+			},
+		},
+		{
+			// The British table makes straights worth more than flushes,
+			// which are much easier to build on the grid
+			ID:   "british",
+			Name: "British",
+			Points: map[entity.HandType]int{
+				entity.RoyalFlush: 30, entity.StraightFlush: 30, entity.FourOfAKind: 16, entity.FullHouse: 10,
+				entity.Flush: 5, entity.Straight: 12, entity.ThreeOfAKind: 6, entity.TwoPair: 3, entity.OnePair: 1,
+			},
+		},
+	}
+}
+
+// FindScoring returns the built-in scoring table with the given ID or name,
+// ignoring case.
+func FindScoring(name string) (Scoring, error) {
+	var ids []string
+	for _, s := range Scorings() {
+		if strings.EqualFold(s.ID, name) || strings.EqualFold(s.Name, name) {
+			return s, nil
+		}
+		ids = append(ids, s.ID)
+	}
+	return Scoring{}, fmt.Errorf("unknown scoring %q (available: %s)", name, strings.Join(ids, ", "))
+}
+
+// Cell is a position on the grid.
+type Cell struct {
+	Row, Col int
+}
+
+// String returns the cell as a column letter and a row number, like "B3".
+func (c Cell) String() string {
+	return fmt.Sprintf("%c%d", 'A'+c.Col, c.Row+1)
+}
+
+// Line is a row or a column of the grid.
+type Line struct {
+	// Name is like "Row 2" or "Column C".
+	Name  string
+	Cards []entity.Trump
+	// HandType and Points are only set once the line is full.
+	HandType entity.HandType
+	Points   int
+}
+
+// Full reports whether all cells of the line are filled.
+func (l Line) Full() bool {
+	return len(l.Cards) == Size
+}
+
+// Game is a deal of Poker Squares.
+type Game struct {
+	Scoring Scoring
+	// Seed decides the deal, so players can compare scores on the same one.
+	Seed int64
+	Grid [Size][Size]entity.Trump
+
+	cards  []entity.Trump
+	placed int
+}
+
+// NewGame deals 25 cards from a deck shuffled with the seed.
+func NewGame(scoring Scoring, seed int64) *Game {
+	deck := entity.NewDeck()
+	deck.ShuffleWith(rand.New(rand.NewSource(seed))) // #nosec G404 -- seeded so deals can be shared
+	return &Game{
+		Scoring: scoring,
+		Seed:    seed,
+		cards:   deck.Draw(Size * Size),
+	}
+}
+
+// Next returns the card to place, or false when the grid is full.
+func (g *Game) Next() (entity.Trump, bool) {
+	if g.Done() {
+		return entity.Trump{}, false
+	}
+	return g.cards[g.placed], true
+}
+
+// Placed returns the number of cards on the grid.
+func (g *Game) Placed() int {
+	return g.placed
+}
+
+// Done reports whether every card has been placed.
+func (g *Game) Done() bool {
+	return g.placed == len(g.cards)
+}
+
+// Filled reports whether a card has been placed on the cell.
+func (g *Game) Filled(c Cell) bool {
+	return g.Grid[c.Row][c.Col] != entity.Trump{}
+}
+
+// Empty returns the cells without a card, row by row.
+func (g *Game) Empty() []Cell {
+	var cells []Cell
+	for row := 0; row < Size; row++ {
+		for col := 0; col < Size; col++ {
+			if c := (Cell{row, col}); !g.Filled(c) {
+				cells = append(cells, c)
+			}
+		}
+	}
+	return cells
+}
+
+// Place puts the next card on the cell.
+func (g *Game) Place(c Cell) error {
+	if c.Row < 0 || c.Row >= Size || c.Col < 0 || c.Col >= Size {
+		return fmt.Errorf("cell %d,%d is off the grid", c.Row, c.Col)
+	}
+	card, ok := g.Next()
+	if !ok {
+		return ErrGridFull
+	}
+	if g.Filled(c) {
+		return fmt.Errorf("%w: %s", ErrOccupied, c)
+	}
+	g.Grid[c.Row][c.Col] = card
+	g.placed++
+	return nil
+}
+
+// Lines returns the rows from the top, then the columns from the left.
+func (g *Game) Lines() []Line {
+	var lines []Line
+	for row := 0; row < Size; row++ {
+		var cells []Cell
+		for col := 0; col < Size; col++ {
+			cells = append(cells, Cell{row, col})
+		}
+		lines = append(lines, g.line(fmt.Sprintf("Row %d", row+1), cells))
+	}
+	for col := 0; col < Size; col++ {
+		var cells []Cell
+		for row := 0; row < Size; row++ {
+			cells = append(cells, Cell{row, col})
+		}
+		lines = append(lines, g.line(fmt.Sprintf("Column %c", 'A'+col), cells))
+	}
+	return lines
+}
+
+func (g *Game) line(name string, cells []Cell) Line {
+	l := Line{Name: name}
+	for _, c := range cells {
+		if g.Filled(c) {
+			l.Cards = append(l.Cards, g.Grid[c.Row][c.Col])
+		}
+	}
+	if l.Full() {
+		l.HandType = entity.EvaluateHand(l.Cards)
+		l.Points = g.Scoring.Points[l.HandType]
+	}
+	return l
+}
+
+// Score returns the points of the full lines.
+func (g *Game) Score() int {
+	score := 0
+	for _, l := range g.Lines() {
+		score += l.Points
+	}
+	return score
+}
@@ -0,0 +1,144 @@
+package squares
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/litencatt/pkr/entity"
+)
+
+func cards(t *testing.T, s string) []entity.Trump {
+	t.Helper()
+	hand, err := entity.ParseCards(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hand
+}
+
+func scoring(t *testing.T, id string) Scoring {
+	t.Helper()
+	s, err := FindScoring(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// fill places the cards row by row.
+func fill(t *testing.T, g *Game) {
+	t.Helper()
+	for row := 0; row < Size; row++ {
+		for col := 0; col < Size; col++ {
+			if err := g.Place(Cell{row, col}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestScore(t *testing.T) {
+	g := NewGame(scoring(t, "american"), 1)
+	g.cards = cards(t, `
+		Ah Kh Qh Jh Th
+		As Ad Ac 2s 2d
+		9s 9d 4c 4h 3s
+		8c 7d 6c 5s 3d
+		7c 2h 4d 6d 3c`)
+	fill(t, g)
+
+	want := map[string]entity.HandType{
+		"Row 1":    entity.RoyalFlush,
+		"Row 2":    entity.FullHouse,
+		"Row 3":    entity.TwoPair,
+		"Row 4":    entity.HighCard,
+		"Row 5":    entity.HighCard,
+		"Column A": entity.OnePair,
+		"Column B": entity.HighCard,
+		"Column C": entity.OnePair,
+		"Column D": entity.HighCard,
+		"Column E": entity.ThreeOfAKind,
+	}
+	lines := g.Lines()
+	if len(lines) != 2*Size {
+		t.Fatalf("Lines() returned %d lines", len(lines))
+	}
+	for _, l := range lines {
+		if l.HandType != want[l.Name] {
+			t.Errorf("%s %v = %s, want %s", l.Name, l.Cards, l.HandType, want[l.Name])
+		}
+	}
+	// 100 + 25 + 5 + 2 + 2 + 10
+	if got := g.Score(); got != 144 {
+		t.Errorf("Score() = %d, want 144", got)
+	}
+
+	g.Scoring = scoring(t, "British")
+	// 30 + 10 + 3 + 1 + 1 + 6
+	if got := g.Score(); got != 51 {
+		t.Errorf("British Score() = %d, want 51", got)
+	}
+}
+
+func TestPlace(t *testing.T) {
+	g := NewGame(scoring(t, "american"), 7)
+	first, _ := g.Next()
+	if err := g.Place(Cell{2, 3}); err != nil {
+		t.Fatal(err)
+	}
+	if g.Grid[2][3] != first || g.Placed() != 1 {
+		t.Errorf("after Place: D3 = %v, placed %d", g.Grid[2][3], g.Placed())
+	}
+	if err := g.Place(Cell{2, 3}); !errors.Is(err, ErrOccupied) {
+		t.Errorf("Place on a filled cell error = %v", err)
+	}
+	if err := g.Place(Cell{5, 0}); err == nil {
+		t.Error("Place off the grid did not fail")
+	}
+	if len(g.Empty()) != Size*Size-1 {
+		t.Errorf("Empty() has %d cells", len(g.Empty()))
+	}
+	// Lines only score when full
+	if g.Score() != 0 {
+		t.Errorf("Score() = %d with one card", g.Score())
+	}
+
+	g = NewGame(scoring(t, "american"), 7)
+	fill(t, g)
+	if !g.Done() {
+		t.Error("Done() = false on a full grid")
+	}
+	if err := g.Place(Cell{0, 0}); !errors.Is(err, ErrGridFull) {
+		t.Errorf("Place on a full grid error = %v", err)
+	}
+	if _, ok := g.Next(); ok {
+		t.Error("Next() returned a card on a full grid")
+	}
+}
+
+func TestSeedDeals(t *testing.T) {
+	a, b, c := NewGame(Scorings()[0], 42), NewGame(Scorings()[1], 42), NewGame(Scorings()[0], 43)
+	if len(a.cards) != Size*Size {
+		t.Fatalf("dealt %d cards", len(a.cards))
+	}
+	same := true
+	for i := range a.cards {
+		if a.cards[i] != b.cards[i] {
+			t.Fatalf("card %d differs for the same seed: %v and %v", i, a.cards[i], b.cards[i])
+		}
+		same = same && a.cards[i] == c.cards[i]
+	}
+	if same {
+		t.Error("seeds 42 and 43 dealt the same cards")
+	}
+}
+
+func TestCell(t *testing.T) {
+	if got := (Cell{Row: 2, Col: 1}).String(); got != "B3" {
+		t.Errorf("String() = %q, want B3", got)
+	}
+	if _, err := FindScoring("french"); err == nil || !strings.Contains(err.Error(), "available: american, british") {
+		t.Errorf("FindScoring(french) error = %v", err)
+	}
+}
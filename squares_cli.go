@@ -0,0 +1,109 @@
+package pkr
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/litencatt/pkr/squares"
+)
+
+// SquaresCLI plays Poker Squares with survey prompts.
+type SquaresCLI struct {
+	game *squares.Game
+	out  io.Writer
+}
+
+func NewSquaresCLI(scoring string, seed int64) (*SquaresCLI, error) {
+	s, err := squares.FindScoring(scoring)
+	if err != nil {
+		return nil, err
+	}
+	return &SquaresCLI{
+		game: squares.NewGame(s, seed),
+		out:  os.Stdout,
+	}, nil
+}
+
+// Run asks where to place every card, then shows the final score.
+func (cli *SquaresCLI) Run() error {
+	g := cli.game
+	for {
+		ClearTerminal()
+		printBox(cli.out, "🟩 POKER SQUARES", fmt.Sprintf("Scoring: %s   Deal: %d", g.Scoring.Name, g.Seed))
+		cli.printGrid()
+
+		card, ok := g.Next()
+		if !ok {
+			break
+		}
+
+		var options []string
+		cells := g.Empty()
+		for _, c := range cells {
+			options = append(options, c.String())
+		}
+		var i int
+		prompt := &survey.Select{
+			Message: fmt.Sprintf("Place %s (card %d of %d):", cardLabel(card), g.Placed()+1, squares.Size*squares.Size),
+			Options: options,
+		}
+		if err := ask(prompt, &i, survey.WithPageSize(10)); err != nil {
+			return err
+		}
+		if err := g.Place(cells[i]); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(cli.out, "🏆 Final score: %d\n", g.Score())
+	fmt.Fprintf(cli.out, "Play the same deal again with `pkr squares --scoring %s --seed %d`.\n", g.Scoring.ID, g.Seed)
+	return nil
+}
+
+// printGrid shows the grid with the hand and points of every full row at
+// its right and of every full column below it.
+func (cli *SquaresCLI) printGrid() {
+	g := cli.game
+	lines := g.Lines()
+
+	fmt.Fprint(cli.out, "   ")
+	for col := 0; col < squares.Size; col++ {
+		fmt.Fprintf(cli.out, "  %c  ", 'A'+col)
+	}
+	fmt.Fprintln(cli.out)
+	for row := 0; row < squares.Size; row++ {
+		fmt.Fprintf(cli.out, " %d ", row+1)
+		for col := 0; col < squares.Size; col++ {
+			label := "·"
+			if c := (squares.Cell{Row: row, Col: col}); g.Filled(c) {
+				label = cardLabel(g.Grid[row][col])
+			}
+			fmt.Fprintf(cli.out, " %s ", padLeft(label, 3))
+		}
+		fmt.Fprintf(cli.out, "  %s\n", lineScore(lines[row]))
+	}
+	fmt.Fprintln(cli.out)
+	for _, l := range lines[squares.Size:] {
+		if l.Full() {
+			fmt.Fprintf(cli.out, " %-9s %s\n", l.Name, lineScore(l))
+		}
+	}
+	fmt.Fprintf(cli.out, "\nScore: %d\n\n", g.Score())
+}
+
+// padLeft pads s with spaces to width runes. Unlike %3s it counts the suit
+// symbols as one column instead of three bytes.
+func padLeft(s string, width int) string {
+	return strings.Repeat(" ", max(width-utf8.RuneCountInString(s), 0)) + s
+}
+
+func lineScore(l squares.Line) string {
+	if !l.Full() {
+		return ""
+	}
+	return fmt.Sprintf("%-15s %3d", l.HandType, l.Points)
+}
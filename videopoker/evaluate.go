@@ -0,0 +1,160 @@
+package videopoker
+
+import (
+	"sort"
+
+	"github.com/litencatt/pkr/entity"
+)
+
+// Evaluate returns the best paying category of a five-card hand on the
+// machine, or an empty category when the hand pays nothing. Wild cards
+// stand for whatever card makes the best category.
+func (m Machine) Evaluate(hand []entity.Trump) Category {
+	if len(hand) != 5 {
+		return ""
+	}
+	s := m.shape(hand)
+	for _, c := range m.Categories {
+		if s.is(c) {
+			return c
+		}
+	}
+	return ""
+}
+
+// shape is what the categories are decided from: the wild cards and the
+// ranks and suits of the natural cards.
+type shape struct {
+	wild   int
+	counts [15]int
+	// groups are the sizes of the natural rank groups, largest first.
+	groups []int
+	suited bool
+}
+
+func (m Machine) shape(hand []entity.Trump) shape {
+	s := shape{suited: true}
+	var suit entity.Suit
+	for _, card := range hand {
+		if m.IsWild(card) {
+			s.wild++
+			continue
+		}
+		if suit != "" && card.Suit != suit {
+			s.suited = false
+		}
+		suit = card.Suit
+		s.counts[card.GetRankNumber()]++
+	}
+	for _, n := range s.counts {
+		if n > 0 {
+			s.groups = append(s.groups, n)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(s.groups)))
+	// Pad so that the two largest groups can always be read
+	s.groups = append(s.groups, 0, 0)
+	return s
+}
+
+func (s shape) is(c Category) bool {
+	switch c {
+	case RoyalFlush:
+		return s.wild == 0 && s.suited && s.royal()
+	case FourDeuces:
+		return s.wild == 4
+	case WildRoyalFlush:
+		return s.wild > 0 && s.suited && s.royal()
+	case FiveOfAKind:
+		return s.wild > 0 && s.groups[0]+s.wild >= 5
+	case StraightFlush:
+		return s.suited && s.straight()
+	case FourAces:
+		return s.quads(14, 14)
+	case FourTwosFours:
+		return s.quads(2, 4)
+	case FourFivesKings:
+		return s.quads(5, 13)
+	case FourOfAKind:
+		return s.groups[0]+s.wild >= 4
+	case FullHouse:
+		return max(0, 3-s.groups[0])+max(0, 2-s.groups[1]) <= s.wild
+	case Flush:
+		return s.suited
+	case Straight:
+		return s.straight()
+	case ThreeOfAKind:
+		return s.groups[0]+s.wild >= 3
+	case TwoPair:
+		return s.groups[0] >= 2 && s.groups[1] >= 2
+	case JacksOrBetter:
+		return s.pairOf(11)
+	case KingsOrBetter:
+		return s.pairOf(13)
+	}
+	return false
+}
+
+// straight reports whether the wild cards can fill the gaps between the
+// natural cards, with the ace high or low.
+func (s shape) straight() bool {
+	if s.groups[0] > 1 {
+		return false
+	}
+	low, high, lowAce := 15, 0, 0
+	for rank := 2; rank <= 14; rank++ {
+		if s.counts[rank] == 0 {
+			continue
+		}
+		low = min(low, rank)
+		high = max(high, rank)
+		if rank != 14 {
+			lowAce = max(lowAce, rank)
+		}
+	}
+	if high == 0 {
+		return true
+	}
+	if s.counts[14] > 0 && lowAce-1 <= 4 {
+		return true
+	}
+	return high-low <= 4
+}
+
+// royal reports whether the natural cards are all different and Ten or
+// higher.
+func (s shape) royal() bool {
+	if s.groups[0] > 1 {
+		return false
+	}
+	for rank := 2; rank < 10; rank++ {
+		if s.counts[rank] > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// quads reports whether the hand has four natural cards of a rank from low
+// to high.
+func (s shape) quads(low, high int) bool {
+	for rank := low; rank <= high; rank++ {
+		if s.counts[rank] == 4 {
+			return true
+		}
+	}
+	return false
+}
+
+// pairOf reports whether the hand has a pair of the given rank or higher.
+func (s shape) pairOf(rank int) bool {
+	if s.wild >= 2 {
+		return true
+	}
+	for r := rank; r <= 14; r++ {
+		if s.counts[r]+s.wild >= 2 && s.counts[r] > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,198 @@
+package videopoker
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/litencatt/pkr/entity"
+)
+
+// cards parses cards like "Ah Td 2c", with "X" for the joker.
+func cards(t *testing.T, s string) []entity.Trump {
+	t.Helper()
+	var hand []entity.Trump
+	for _, name := range strings.Fields(s) {
+		if name == "X" {
+			hand = append(hand, Joker)
+			continue
+		}
+		parsed, err := entity.ParseCards(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hand = append(hand, parsed...)
+	}
+	return hand
+}
+
+func machine(t *testing.T, id string) Machine {
+	t.Helper()
+	m, err := FindMachine(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		machine string
+		hand    string
+		want    Category
+	}{
+		{"jacks", "Ah Kh Qh Jh Th", RoyalFlush},
+		{"jacks", "9h Kh Qh Jh Th", StraightFlush},
+		{"jacks", "Ah 2h 3h 4h 5h", StraightFlush},
+		{"jacks", "7c 7d 7h 7s 2c", FourOfAKind},
+		{"jacks", "7c 7d 7h 2s 2c", FullHouse},
+		{"jacks", "Ac 9c 7c 4c 2c", Flush},
+		{"jacks", "Ac 2d 3h 4s 5c", Straight},
+		{"jacks", "Tc Jd Qh Ks Ac", Straight},
+		{"jacks", "Qc Kd Ah 2s 3c", ""},
+		{"jacks", "3c 3d 3h Ks Ac", ThreeOfAKind},
+		{"jacks", "3c 3d 4h 4s Ac", TwoPair},
+		{"jacks", "Jc Jd 4h 5s Ac", JacksOrBetter},
+		{"jacks", "Tc Td 4h 5s Ac", ""},
+		{"jacks", "2c 4d 6h 8s Tc", ""},
+
+		{"bonus", "Ac Ad Ah As 2c", FourAces},
+		{"bonus", "3c 3d 3h 3s Ac", FourTwosFours},
+		{"bonus", "Kc Kd Kh Ks Ac", FourFivesKings},
+		{"bonus", "5c 5d 5h 5s Ac", FourFivesKings},
+		{"bonus", "Ac Ad Ah Ks Kc", FullHouse},
+
+		{"deuces", "Ah Kh Qh Jh Th", RoyalFlush},
+		{"deuces", "2h 2c 2d 2s Th", FourDeuces},
+		{"deuces", "Ah 2c Qh Jh Th", WildRoyalFlush},
+		{"deuces", "Ah 2c 2d Jh Th", WildRoyalFlush},
+		{"deuces", "7h 7c 7d 2s 2h", FiveOfAKind},
+		{"deuces", "7h 2c 2d 2s Kh", FourOfAKind},
+		{"deuces", "7h 2c 5h 4h 3h", StraightFlush},
+		{"deuces", "Ah 2c 5h 4h 3h", StraightFlush},
+		{"deuces", "Ah 2c 3h 4h 9h", Flush},
+		{"deuces", "7h 7c 8d 8s 2h", FullHouse},
+		{"deuces", "7h 8c 9d Ts 2h", Straight},
+		{"deuces", "7h 8c Jd Ts 2h", Straight},
+		{"deuces", "Ah Kc 2d Ts 2h", Straight},
+		{"deuces", "7h 7c 2d Ts Kh", ThreeOfAKind},
+		{"deuces", "7h 7c 8d 8s Kh", ""},
+		{"deuces", "Ah Ac 3d 8s Kh", ""},
+
+		{"joker", "X Kh Qh Jh Th", WildRoyalFlush},
+		{"joker", "X Kc Kh Kd Ks", FiveOfAKind},
+		{"joker", "X 9h Qh Jh Th", StraightFlush},
+		{"joker", "X 9h 9c 9d Th", FourOfAKind},
+		{"joker", "X 9h 9c Td Th", FullHouse},
+		{"joker", "X 9h 3h Td Th", ThreeOfAKind},
+		{"joker", "X 9h 3c 4d 6h", ""},
+		{"joker", "X 9h 3c 4d Kh", KingsOrBetter},
+		{"joker", "X 9h 3c 4d Ah", KingsOrBetter},
+		{"joker", "Qs 9h Qc 4d Ah", ""},
+		{"joker", "Qs 9h Qc 9d Ah", TwoPair},
+	}
+	for _, tt := range tests {
+		t.Run(tt.machine+" "+tt.hand, func(t *testing.T) {
+			if got := machine(t, tt.machine).Evaluate(cards(t, tt.hand)); got != tt.want {
+				t.Errorf("Evaluate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// Jacks or Better has no wild cards, so it must agree with the plain poker
+// evaluator on every hand.
+func TestEvaluateJacksMatchesEntity(t *testing.T) {
+	want := map[entity.HandType]Category{
+		entity.RoyalFlush:    RoyalFlush,
+		entity.StraightFlush: StraightFlush,
+		entity.FourOfAKind:   FourOfAKind,
+		entity.FullHouse:     FullHouse,
+		entity.Flush:         Flush,
+		entity.Straight:      Straight,
+		entity.ThreeOfAKind:  ThreeOfAKind,
+		entity.TwoPair:       TwoPair,
+	}
+	m := machine(t, "jacks")
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 20000; i++ {
+		deck := m.NewDeck()
+		deck.ShuffleWith(rnd)
+		hand := deck.Draw(5)
+
+		got := m.Evaluate(hand)
+		ht := entity.EvaluateHand(hand)
+		expected := want[ht]
+		if ht == entity.OnePair && highPair(hand) >= 11 {
+			expected = JacksOrBetter
+		}
+		if got != expected {
+			t.Fatalf("Evaluate(%v) = %q, entity says %q", hand, got, ht)
+		}
+	}
+}
+
+func highPair(hand []entity.Trump) int {
+	seen := map[int]bool{}
+	for _, card := range hand {
+		if seen[card.GetRankNumber()] {
+			return card.GetRankNumber()
+		}
+		seen[card.GetRankNumber()] = true
+	}
+	return 0
+}
+
+func TestPayout(t *testing.T) {
+	m := machine(t, "jacks")
+	p, err := m.FindPaytable("9/6")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		category Category
+		bet      int
+		want     int
+	}{
+		{RoyalFlush, 1, 250},
+		{RoyalFlush, 4, 1000},
+		{RoyalFlush, MaxBet, 4000},
+		{FullHouse, 3, 27},
+		{Flush, 5, 30},
+		{JacksOrBetter, 2, 2},
+		{"", 5, 0},
+	}
+	for _, tt := range tests {
+		if got := p.Payout(tt.category, tt.bet); got != tt.want {
+			t.Errorf("Payout(%q, %d) = %d, want %d", tt.category, tt.bet, got, tt.want)
+		}
+	}
+}
+
+func TestMachines(t *testing.T) {
+	for _, m := range Machines() {
+		if len(m.Paytables) == 0 {
+			t.Errorf("%s has no paytables", m.ID)
+		}
+		for _, p := range m.Paytables {
+			for _, c := range m.Categories {
+				if p.Pays[c] == 0 {
+					t.Errorf("%s %s does not pay %s", m.ID, p.Name, c)
+				}
+			}
+		}
+		if got := len(m.NewDeck()); got != 52+m.Jokers {
+			t.Errorf("%s deck has %d cards", m.ID, got)
+		}
+	}
+
+	if _, err := FindMachine("Deuces Wild"); err != nil {
+		t.Error(err)
+	}
+	if _, err := FindMachine("pai gow"); err == nil || !strings.Contains(err.Error(), "available: jacks") {
+		t.Errorf("FindMachine(pai gow) error = %v", err)
+	}
+	if _, err := machine(t, "jacks").FindPaytable("10/7"); err == nil {
+		t.Error("FindPaytable(10/7) did not fail")
+	}
+}
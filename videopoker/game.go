@@ -0,0 +1,223 @@
+package videopoker
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/litencatt/pkr/entity"
+)
+
+// DoubleUpCards is the number of face-down cards to pick from in a
+// double-up.
+const DoubleUpCards = 4
+
+var (
+	// ErrNoCredits is returned by Deal when the credits cannot cover a bet.
+	ErrNoCredits = errors.New("not enough credits")
+	// ErrWrongTime is returned when an action is not possible at this
+	// point of the hand.
+	ErrWrongTime = errors.New("not possible now")
+)
+
+type phase int
+
+const (
+	idle phase = iota
+	dealt
+	won
+	doubling
+)
+
+// Stats are the totals of one or more sessions on a machine.
+type Stats struct {
+	Sessions int `json:"sessions"`
+	Hands    int `json:"hands"`
+	Wagered  int `json:"wagered"`
+	// Won is the credits paid out, after double-ups.
+	Won           int              `json:"won"`
+	BestWin       int              `json:"best_win"`
+	HandTypes     map[Category]int `json:"hand_types"`
+	DoubleUpsWon  int              `json:"double_ups_won"`
+	DoubleUpsLost int              `json:"double_ups_lost"`
+}
+
+// Add adds other to the totals.
+func (s *Stats) Add(other Stats) {
+	s.Sessions += other.Sessions
+	s.Hands += other.Hands
+	s.Wagered += other.Wagered
+	s.Won += other.Won
+	s.BestWin = max(s.BestWin, other.BestWin)
+	if s.HandTypes == nil {
+		s.HandTypes = map[Category]int{}
+	}
+	for c, n := range other.HandTypes {
+		s.HandTypes[c] += n
+	}
+	s.DoubleUpsWon += other.DoubleUpsWon
+	s.DoubleUpsLost += other.DoubleUpsLost
+}
+
+// Result is the outcome of a draw.
+type Result struct {
+	// Category is empty when the hand pays nothing.
+	Category Category
+	Win      int
+}
+
+// DoubleUp is a double-or-nothing gamble on the last win: the player picks
+// one of the face-down cards and wins when it beats the dealer's card.
+type DoubleUp struct {
+	Stake  int
+	Dealer entity.Trump
+	// Cards are revealed once one is picked.
+	Cards  []entity.Trump
+	Picked int
+	// Win is what the pick paid: twice the stake, the stake back on a tie,
+	// or nothing.
+	Win int
+}
+
+// Game is a session on a machine.
+type Game struct {
+	Machine  Machine
+	Paytable Paytable
+	Credits  int
+	Bet      int
+	Hand     []entity.Trump
+	Stats    Stats
+
+	rnd      *rand.Rand
+	deck     entity.Deck
+	phase    phase
+	lastWin  int
+	doubleUp *DoubleUp
+}
+
+// NewGame starts a session with the given credits. The seed decides every
+// deal, so the same seed and plays give the same session.
+func NewGame(machine Machine, paytable Paytable, credits int, seed int64) *Game {
+	return &Game{
+		Machine:  machine,
+		Paytable: paytable,
+		Credits:  credits,
+		Stats:    Stats{Sessions: 1, HandTypes: map[Category]int{}},
+		rnd:      rand.New(rand.NewSource(seed)), // #nosec G404 -- seeded for reproducible sessions
+	}
+}
+
+// Deal takes the bet from the credits and deals five cards.
+func (g *Game) Deal(bet int) error {
+	if g.phase == dealt || g.phase == doubling {
+		return fmt.Errorf("%w: the hand is not over", ErrWrongTime)
+	}
+	if bet < 1 || bet > MaxBet {
+		return fmt.Errorf("bet must be 1 to %d coins, got %d", MaxBet, bet)
+	}
+	if bet > g.Credits {
+		return fmt.Errorf("%w: %d left for a bet of %d", ErrNoCredits, g.Credits, bet)
+	}
+
+	g.Credits -= bet
+	g.Bet = bet
+	g.Stats.Hands++
+	g.Stats.Wagered += bet
+	g.deck = g.Machine.NewDeck()
+	g.deck.ShuffleWith(g.rnd)
+	g.Hand = append([]entity.Trump(nil), g.deck.Draw(5)...)
+	g.phase = dealt
+	return nil
+}
+
+// Draw replaces the cards that are not held and pays the final hand.
+func (g *Game) Draw(held [5]bool) (Result, error) {
+	if g.phase != dealt {
+		return Result{}, fmt.Errorf("%w: no hand has been dealt", ErrWrongTime)
+	}
+	for i := range g.Hand {
+		if !held[i] {
+			g.Hand[i] = g.deck.Draw(1)[0]
+		}
+	}
+
+	r := Result{Category: g.Machine.Evaluate(g.Hand)}
+	r.Win = g.Paytable.Payout(r.Category, g.Bet)
+	g.Credits += r.Win
+	g.Stats.Won += r.Win
+	g.Stats.BestWin = max(g.Stats.BestWin, r.Win)
+	if r.Category != "" {
+		g.Stats.HandTypes[r.Category]++
+	}
+
+	g.lastWin = r.Win
+	g.phase = idle
+	if r.Win > 0 {
+		g.phase = won
+	}
+	return r, nil
+}
+
+// CanDoubleUp reports whether the last win can be gambled.
+func (g *Game) CanDoubleUp() bool {
+	return g.phase == won
+}
+
+// LastWin returns what the last draw or double-up paid.
+func (g *Game) LastWin() int {
+	return g.lastWin
+}
+
+// StartDoubleUp stakes the last win and deals the dealer's card face up.
+// A won double-up can be doubled again.
+func (g *Game) StartDoubleUp() (*DoubleUp, error) {
+	if g.phase != won {
+		return nil, fmt.Errorf("%w: there is no win to double", ErrWrongTime)
+	}
+
+	deck := entity.NewDeck()
+	deck.ShuffleWith(g.rnd)
+	g.doubleUp = &DoubleUp{
+		Stake:  g.lastWin,
+		Dealer: deck.Draw(1)[0],
+		Picked: -1,
+	}
+	g.doubleUp.Cards = append([]entity.Trump(nil), deck.Draw(DoubleUpCards)...)
+	g.Credits -= g.lastWin
+	g.Stats.Won -= g.lastWin
+	g.phase = doubling
+	return g.doubleUp, nil
+}
+
+// Pick turns over one of the face-down cards. Aces are high and a tie
+// returns the stake.
+func (g *Game) Pick(i int) (*DoubleUp, error) {
+	if g.phase != doubling {
+		return nil, fmt.Errorf("%w: no double-up has started", ErrWrongTime)
+	}
+	if i < 0 || i >= DoubleUpCards {
+		return nil, fmt.Errorf("pick a card from 1 to %d", DoubleUpCards)
+	}
+
+	d := g.doubleUp
+	d.Picked = i
+	switch dealer, picked := d.Dealer.GetRankNumber(), d.Cards[i].GetRankNumber(); {
+	case picked > dealer:
+		d.Win = 2 * d.Stake
+		g.Stats.DoubleUpsWon++
+	case picked == dealer:
+		d.Win = d.Stake
+	default:
+		g.Stats.DoubleUpsLost++
+	}
+	g.Credits += d.Win
+	g.Stats.Won += d.Win
+	g.Stats.BestWin = max(g.Stats.BestWin, d.Win)
+
+	g.lastWin = d.Win
+	g.phase = idle
+	if d.Win > 0 {
+		g.phase = won
+	}
+	return d, nil
+}
@@ -0,0 +1,165 @@
+package videopoker
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func newGame(t *testing.T, id string, credits int, seed int64) *Game {
+	t.Helper()
+	m := machine(t, id)
+	return NewGame(m, m.Paytables[0], credits, seed)
+}
+
+func TestDeal(t *testing.T) {
+	g := newGame(t, "jacks", 3, 1)
+	if _, err := g.Draw([5]bool{}); !errors.Is(err, ErrWrongTime) {
+		t.Errorf("Draw before Deal error = %v", err)
+	}
+	for _, bet := range []int{0, MaxBet + 1} {
+		if err := g.Deal(bet); err == nil {
+			t.Errorf("Deal(%d) did not fail", bet)
+		}
+	}
+	if err := g.Deal(4); !errors.Is(err, ErrNoCredits) {
+		t.Errorf("Deal(4) with 3 credits error = %v", err)
+	}
+
+	if err := g.Deal(2); err != nil {
+		t.Fatal(err)
+	}
+	if g.Credits != 1 || len(g.Hand) != 5 {
+		t.Errorf("after Deal(2): credits %d, hand %v", g.Credits, g.Hand)
+	}
+	if err := g.Deal(1); !errors.Is(err, ErrWrongTime) {
+		t.Errorf("Deal during a hand error = %v", err)
+	}
+}
+
+func TestDrawHolds(t *testing.T) {
+	g := newGame(t, "joker", 10, 7)
+	if err := g.Deal(1); err != nil {
+		t.Fatal(err)
+	}
+	dealt := append(g.Hand[:0:0], g.Hand...)
+	held := [5]bool{true, false, true, false, false}
+	if _, err := g.Draw(held); err != nil {
+		t.Fatal(err)
+	}
+	for i, card := range g.Hand {
+		if held[i] != (card == dealt[i]) {
+			t.Errorf("card %d: dealt %v, now %v, held %v", i, dealt[i], card, held[i])
+		}
+	}
+	seen := map[any]bool{}
+	for _, card := range append(dealt, g.Hand...) {
+		seen[card] = true
+	}
+	if len(seen) != 8 {
+		t.Errorf("dealt and drawn %d different cards, want 8", len(seen))
+	}
+}
+
+func TestSeedReplays(t *testing.T) {
+	a, b := newGame(t, "deuces", 100, 42), newGame(t, "deuces", 100, 42)
+	for i := 0; i < 20; i++ {
+		if err := a.Deal(5); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.Deal(5); err != nil {
+			t.Fatal(err)
+		}
+		ra, _ := a.Draw([5]bool{true})
+		rb, _ := b.Draw([5]bool{true})
+		if ra != rb || a.Credits != b.Credits {
+			t.Fatalf("hand %d: %v and %v differ", i, a.Hand, b.Hand)
+		}
+		if a.Credits < MaxBet {
+			break
+		}
+	}
+}
+
+func TestDoubleUp(t *testing.T) {
+	g := newGame(t, "jacks", 0, 1)
+	if _, err := g.StartDoubleUp(); !errors.Is(err, ErrWrongTime) {
+		t.Errorf("StartDoubleUp without a win error = %v", err)
+	}
+
+	// Stage a win of 10 credits
+	g.phase, g.lastWin, g.Credits = won, 10, 10
+	d, err := g.StartDoubleUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Credits != 0 || d.Stake != 10 || len(d.Cards) != DoubleUpCards {
+		t.Fatalf("StartDoubleUp: credits %d, stake %d, %d cards", g.Credits, d.Stake, len(d.Cards))
+	}
+	if _, err := g.Pick(DoubleUpCards); err == nil {
+		t.Error("Pick(DoubleUpCards) did not fail")
+	}
+
+	d, err = g.Pick(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dealer, picked := d.Dealer.GetRankNumber(), d.Cards[0].GetRankNumber()
+	want := 0
+	switch {
+	case picked > dealer:
+		want = 20
+	case picked == dealer:
+		want = 10
+	}
+	if d.Win != want || g.Credits != want {
+		t.Errorf("%v against %v: win %d, credits %d, want %d", d.Cards[0], d.Dealer, d.Win, g.Credits, want)
+	}
+	if g.CanDoubleUp() != (want > 0) {
+		t.Errorf("CanDoubleUp() = %v after winning %d", g.CanDoubleUp(), want)
+	}
+}
+
+// Whatever is played, the credits always move by what was won less what
+// was wagered.
+func TestStatsBalance(t *testing.T) {
+	for _, m := range Machines() {
+		g := NewGame(m, m.Paytables[0], 1000, 3)
+		rnd := rand.New(rand.NewSource(3))
+		for g.Credits >= MaxBet && g.Stats.Hands < 500 {
+			if err := g.Deal(1 + rnd.Intn(MaxBet)); err != nil {
+				t.Fatal(err)
+			}
+			var held [5]bool
+			for i := range held {
+				held[i] = rnd.Intn(2) == 0
+			}
+			if _, err := g.Draw(held); err != nil {
+				t.Fatal(err)
+			}
+			for g.CanDoubleUp() && rnd.Intn(2) == 0 {
+				if _, err := g.StartDoubleUp(); err != nil {
+					t.Fatal(err)
+				}
+				if _, err := g.Pick(rnd.Intn(DoubleUpCards)); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+		if got := 1000 + g.Stats.Won - g.Stats.Wagered; got != g.Credits {
+			t.Errorf("%s: credits %d, stats say %d", m.ID, g.Credits, got)
+		}
+	}
+}
+
+func TestStatsAdd(t *testing.T) {
+	var total Stats
+	total.Add(Stats{Sessions: 1, Hands: 3, Wagered: 15, Won: 5, BestWin: 5, HandTypes: map[Category]int{TwoPair: 1}})
+	total.Add(Stats{Sessions: 1, Hands: 2, Wagered: 2, Won: 8, BestWin: 8, HandTypes: map[Category]int{TwoPair: 1, Flush: 1}, DoubleUpsWon: 1})
+	if total.Sessions != 2 || total.Hands != 5 || total.Wagered != 17 || total.Won != 13 || total.BestWin != 8 || total.DoubleUpsWon != 1 {
+		t.Errorf("Add() = %+v", total)
+	}
+	if total.HandTypes[TwoPair] != 2 || total.HandTypes[Flush] != 1 {
+		t.Errorf("Add() hand types = %v", total.HandTypes)
+	}
+}
@@ -0,0 +1,211 @@
+// Package videopoker plays video poker machines: a five-card draw against
+// a paytable, with wild cards on some machines and a double-up gamble.
+package videopoker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/litencatt/pkr/entity"
+)
+
+// Category is a paying hand on a machine.
+type Category string
+
+const (
+	RoyalFlush     Category = "Royal Flush"
+	FourDeuces     Category = "Four Deuces"
+	WildRoyalFlush Category = "Wild Royal Flush"
+	FiveOfAKind    Category = "Five of a Kind"
+	StraightFlush  Category = "Straight Flush"
+	FourAces       Category = "Four Aces"
+	FourTwosFours  Category = "Four 2s-4s"
+	FourFivesKings Category = "Four 5s-Ks"
+	FourOfAKind    Category = "Four of a Kind"
+	FullHouse      Category = "Full House"
+	Flush          Category = "Flush"
+	Straight       Category = "Straight"
+	ThreeOfAKind   Category = "Three of a Kind"
+	TwoPair        Category = "Two Pair"
+	JacksOrBetter  Category = "Jacks or Better"
+	KingsOrBetter  Category = "Kings or Better"
+)
+
+// MaxBet is the largest bet in coins.
+const MaxBet = 5
+
+// Joker is the wild card added to the deck of Joker Poker.
+var Joker = entity.Trump{Rank: "Joker"}
+
+// Paytable is what each category pays per coin bet.
+type Paytable struct {
+	Name string
+	Pays map[Category]int
+	// MaxBetRoyal is what a natural Royal Flush pays in total with the
+	// maximum bet, which is more than MaxBet times its pay per coin.
+	MaxBetRoyal int
+}
+
+// Payout returns the credits a category pays for a bet.
+func (p Paytable) Payout(c Category, bet int) int {
+	if c == RoyalFlush && bet == MaxBet && p.MaxBetRoyal > 0 {
+		return p.MaxBetRoyal
+	}
+	return p.Pays[c] * bet
+}
+
+// Machine is a video poker variant.
+type Machine struct {
+	ID          string
+	Name        string
+	Description string
+	// DeucesWild makes every 2 wild.
+	DeucesWild bool
+	// Jokers is the number of wild jokers added to the deck.
+	Jokers int
+	// Categories are the paying hands from the best.
+	Categories []Category
+	// Paytables are the available paytables from the best paying.
+	Paytables []Paytable
+}
+
+// NewDeck returns the machine's deck in order.
+func (m Machine) NewDeck() entity.Deck {
+	deck := entity.NewDeck()
+	for i := 0; i < m.Jokers; i++ {
+		deck = append(deck, Joker)
+	}
+	return deck
+}
+
+// IsWild reports whether a card is wild on the machine.
+func (m Machine) IsWild(card entity.Trump) bool {
+	return card == Joker || (m.DeucesWild && card.Rank == entity.Two)
+}
+
+// FindPaytable returns the machine's paytable with the given name, or the
+// first one for an empty name.
+func (m Machine) FindPaytable(name string) (Paytable, error) {
+	if name == "" {
+		return m.Paytables[0], nil
+	}
+	var names []string
+	for _, p := range m.Paytables {
+		if strings.EqualFold(p.Name, name) {
+			return p, nil
+		}
+		names = append(names, p.Name)
+	}
+	return Paytable{}, fmt.Errorf("unknown paytable %q for %s (available: %s)", name, m.Name, strings.Join(names, ", "))
+}
+
+// Machines returns the built-in machines.
+func Machines() []Machine {
+	return []Machine{
+		{
+			ID:          "jacks",
+			Name:        "Jacks or Better",
+			Description: "A pair of Jacks or better pays",
+			Categories:  []Category{RoyalFlush, StraightFlush, FourOfAKind, FullHouse, Flush, Straight, ThreeOfAKind, TwoPair, JacksOrBetter},
+			Paytables: []Paytable{
+				jacksOrBetter("9/6", 9, 6),
+				jacksOrBetter("8/5", 8, 5),
+				jacksOrBetter("7/5", 7, 5),
+				jacksOrBetter("6/5", 6, 5),
+			},
+		},
+		{
+			ID:          "bonus",
+			Name:        "Bonus Poker",
+			Description: "Jacks or Better with bonus pays for four of a kind",
+			Categories:  []Category{RoyalFlush, StraightFlush, FourAces, FourTwosFours, FourFivesKings, FullHouse, Flush, Straight, ThreeOfAKind, TwoPair, JacksOrBetter},
+			Paytables: []Paytable{
+				bonusPoker("8/5", 8, 5),
+				bonusPoker("7/5", 7, 5),
+				bonusPoker("6/5", 6, 5),
+			},
+		},
+		{
+			ID:          "deuces",
+			Name:        "Deuces Wild",
+			Description: "Every 2 is wild, and Three of a Kind is the lowest pay",
+			DeucesWild:  true,
+			Categories:  []Category{RoyalFlush, FourDeuces, WildRoyalFlush, FiveOfAKind, StraightFlush, FourOfAKind, FullHouse, Flush, Straight, ThreeOfAKind},
+			Paytables: []Paytable{
+				{
+					Name: "full-pay",
+					Pays: map[Category]int{
+						RoyalFlush: 250, FourDeuces: 200, WildRoyalFlush: 25, FiveOfAKind: 15, StraightFlush: 9,
+						FourOfAKind: 5, FullHouse: 3, Flush: 2, Straight: 2, ThreeOfAKind: 1,
+					},
+					MaxBetRoyal: 4000,
+				},
+				{
+					Name: "nsud",
+					Pays: map[Category]int{
+						RoyalFlush: 250, FourDeuces: 200, WildRoyalFlush: 25, FiveOfAKind: 16, StraightFlush: 10,
+						FourOfAKind: 4, FullHouse: 4, Flush: 3, Straight: 2, ThreeOfAKind: 1,
+					},
+					MaxBetRoyal: 4000,
+				},
+			},
+		},
+		{
+			ID:          "joker",
+			Name:        "Joker Poker",
+			Description: "A wild joker is added to the deck, and Kings or better pays",
+			Jokers:      1,
+			Categories:  []Category{RoyalFlush, FiveOfAKind, WildRoyalFlush, StraightFlush, FourOfAKind, FullHouse, Flush, Straight, ThreeOfAKind, TwoPair, KingsOrBetter},
+			Paytables: []Paytable{
+				jokerPoker("20/7/5", 20),
+				jokerPoker("17/7/5", 17),
+			},
+		},
+	}
+}
+
+func jacksOrBetter(name string, fullHouse, flush int) Paytable {
+	return Paytable{
+		Name: name,
+		Pays: map[Category]int{
+			RoyalFlush: 250, StraightFlush: 50, FourOfAKind: 25, FullHouse: fullHouse, Flush: flush,
+			Straight: 4, ThreeOfAKind: 3, TwoPair: 2, JacksOrBetter: 1,
+		},
+		MaxBetRoyal: 4000,
+	}
+}
+
+func bonusPoker(name string, fullHouse, flush int) Paytable {
+	return Paytable{
+		Name: name,
+		Pays: map[Category]int{
+			RoyalFlush: 250, StraightFlush: 50, FourAces: 80, FourTwosFours: 40, FourFivesKings: 25,
+			FullHouse: fullHouse, Flush: flush, Straight: 4, ThreeOfAKind: 3, TwoPair: 2, JacksOrBetter: 1,
+		},
+		MaxBetRoyal: 4000,
+	}
+}
+
+func jokerPoker(name string, fourOfAKind int) Paytable {
+	return Paytable{
+		Name: name,
+		Pays: map[Category]int{
+			RoyalFlush: 250, FiveOfAKind: 200, WildRoyalFlush: 100, StraightFlush: 50, FourOfAKind: fourOfAKind,
+			FullHouse: 7, Flush: 5, Straight: 3, ThreeOfAKind: 2, TwoPair: 1, KingsOrBetter: 1,
+		},
+		MaxBetRoyal: 4000,
+	}
+}
+
+// FindMachine returns the built-in machine with the given ID or name,
+// ignoring case.
+func FindMachine(name string) (Machine, error) {
+	var ids []string
+	for _, m := range Machines() {
+		if strings.EqualFold(m.ID, name) || strings.EqualFold(m.Name, name) {
+			return m, nil
+		}
+		ids = append(ids, m.ID)
+	}
+	return Machine{}, fmt.Errorf("unknown machine %q (available: %s)", name, strings.Join(ids, ", "))
+}
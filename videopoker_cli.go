@@ -0,0 +1,271 @@
+package pkr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/litencatt/pkr/entity"
+	"github.com/litencatt/pkr/profile"
+	"github.com/litencatt/pkr/videopoker"
+)
+
+// VideoPokerConfig sets up a video poker session.
+type VideoPokerConfig struct {
+	// Machine is a machine ID or name, like "jacks" or "Deuces Wild".
+	Machine string
+	// Paytable is a paytable of the machine, or empty for its best one.
+	Paytable string
+	Credits  int
+	Seed     int64
+	// DoubleUp offers to gamble every win.
+	DoubleUp bool
+}
+
+// VideoPokerCLI plays video poker with survey prompts.
+type VideoPokerCLI struct {
+	// ProfileDir is where the session is recorded. Sessions are not
+	// recorded when it is empty.
+	ProfileDir string
+	game       *videopoker.Game
+	doubleUp   bool
+	start      int
+	out        io.Writer
+}
+
+func NewVideoPokerCLI(config VideoPokerConfig) (*VideoPokerCLI, error) {
+	if config.Credits <= 0 {
+		return nil, fmt.Errorf("the starting credits must be positive, got %d", config.Credits)
+	}
+	machine, err := videopoker.FindMachine(config.Machine)
+	if err != nil {
+		return nil, err
+	}
+	paytable, err := machine.FindPaytable(config.Paytable)
+	if err != nil {
+		return nil, err
+	}
+	return &VideoPokerCLI{
+		game:     videopoker.NewGame(machine, paytable, config.Credits, config.Seed),
+		doubleUp: config.DoubleUp,
+		start:    config.Credits,
+		out:      os.Stdout,
+	}, nil
+}
+
+// Run plays hands until you cash out or run out of credits, then records
+// the session.
+func (cli *VideoPokerCLI) Run() error {
+	err := cli.play()
+	if errors.Is(err, ErrInterrupted) || err == nil {
+		if recErr := cli.record(); recErr != nil {
+			return recErr
+		}
+	}
+	return err
+}
+
+func (cli *VideoPokerCLI) play() error {
+	g := cli.game
+	for {
+		ClearTerminal()
+		printBox(cli.out, "🎰 "+g.Machine.Name, fmt.Sprintf("Paytable: %s   Credits: %d", g.Paytable.Name, g.Credits))
+		cli.printPaytable()
+
+		if g.Credits == 0 {
+			fmt.Fprintln(cli.out, "💀 You are out of credits.")
+			cli.printSummary()
+			return nil
+		}
+
+		bet, err := cli.askBet()
+		if err != nil || bet == 0 {
+			cli.printSummary()
+			return err
+		}
+		if err := g.Deal(bet); err != nil {
+			return err
+		}
+
+		held, err := cli.askHolds()
+		if err != nil {
+			return err
+		}
+		result, err := g.Draw(held)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(cli.out)
+		fmt.Fprintf(cli.out, "🂠 %s\n", videoPokerLabels(g.Hand))
+		if result.Win == 0 {
+			fmt.Fprintln(cli.out, "No win.")
+		} else {
+			fmt.Fprintf(cli.out, "🎉 %s pays %d\n", result.Category, result.Win)
+			if cli.doubleUp {
+				if err := cli.askDoubleUp(); err != nil {
+					return err
+				}
+			}
+		}
+
+		var next string
+		prompt := &survey.Select{
+			Message: "Continue?",
+			Options: []string{"Next Hand →", "Cash Out"},
+		}
+		if err := ask(prompt, &next); err != nil {
+			return err
+		}
+		if next == "Cash Out" {
+			cli.printSummary()
+			return nil
+		}
+	}
+}
+
+func (cli *VideoPokerCLI) printPaytable() {
+	g := cli.game
+	fmt.Fprintf(cli.out, "  %-18s", "")
+	for bet := 1; bet <= videopoker.MaxBet; bet++ {
+		fmt.Fprintf(cli.out, " %5d", bet)
+	}
+	fmt.Fprintln(cli.out)
+	for _, c := range g.Machine.Categories {
+		fmt.Fprintf(cli.out, "  %-18s", c)
+		for bet := 1; bet <= videopoker.MaxBet; bet++ {
+			fmt.Fprintf(cli.out, " %5d", g.Paytable.Payout(c, bet))
+		}
+		fmt.Fprintln(cli.out)
+	}
+	fmt.Fprintln(cli.out)
+}
+
+// askBet returns the coins to bet, or 0 to cash out.
+func (cli *VideoPokerCLI) askBet() (int, error) {
+	var options []string
+	for bet := 1; bet <= min(videopoker.MaxBet, cli.game.Credits); bet++ {
+		options = append(options, fmt.Sprintf("Bet %d", bet))
+	}
+	options = append(options, "Cash Out")
+
+	var option string
+	prompt := &survey.Select{
+		Message: "Your bet:",
+		Options: options,
+		Default: options[len(options)-2],
+	}
+	if err := ask(prompt, &option); err != nil {
+		return 0, err
+	}
+	bet, _ := strconv.Atoi(strings.TrimPrefix(option, "Bet "))
+	return bet, nil
+}
+
+func (cli *VideoPokerCLI) askHolds() ([5]bool, error) {
+	var options []string
+	for _, card := range cli.game.Hand {
+		options = append(options, videoPokerLabel(card))
+	}
+
+	var holds []int
+	prompt := &survey.MultiSelect{
+		Message: "Select cards to hold",
+		Options: options,
+	}
+	var held [5]bool
+	if err := ask(prompt, &holds, survey.WithPageSize(5)); err != nil {
+		return held, err
+	}
+	for _, i := range holds {
+		held[i] = true
+	}
+	return held, nil
+}
+
+// askDoubleUp offers to gamble the last win until you take it or lose it.
+func (cli *VideoPokerCLI) askDoubleUp() error {
+	g := cli.game
+	for g.CanDoubleUp() {
+		var gamble int
+		prompt := &survey.Select{
+			Message: "Double up?",
+			Options: []string{fmt.Sprintf("Take %d", g.LastWin()), "Double Up"},
+		}
+		if err := ask(prompt, &gamble); err != nil {
+			return err
+		}
+		if gamble == 0 {
+			return nil
+		}
+
+		d, err := g.StartDoubleUp()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cli.out, "Dealer shows %s. Pick a higher card to win %d.\n", cardLabel(d.Dealer), 2*d.Stake)
+
+		var options []string
+		for i := range d.Cards {
+			options = append(options, fmt.Sprintf("Card %d 🂠", i+1))
+		}
+		var pick int
+		if err := ask(&survey.Select{Message: "Pick a card:", Options: options}, &pick); err != nil {
+			return err
+		}
+		if d, err = g.Pick(pick); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cli.out, "You picked %s against %s: ", cardLabel(d.Cards[d.Picked]), cardLabel(d.Dealer))
+		switch {
+		case d.Win > d.Stake:
+			fmt.Fprintf(cli.out, "you win %d!\n", d.Win)
+		case d.Win == d.Stake:
+			fmt.Fprintf(cli.out, "a push, %d back.\n", d.Win)
+		default:
+			fmt.Fprintln(cli.out, "you lose.")
+		}
+	}
+	return nil
+}
+
+func (cli *VideoPokerCLI) printSummary() {
+	s := cli.game.Stats
+	fmt.Fprintln(cli.out)
+	fmt.Fprintf(cli.out, "Hands: %d   Wagered: %d   Won: %d\n", s.Hands, s.Wagered, s.Won)
+	if cli.game.Credits > 0 {
+		fmt.Fprintf(cli.out, "💰 Cashed out %d credits (%+d)\n", cli.game.Credits, cli.game.Credits-cli.start)
+	}
+}
+
+// record adds the session to the profile if a hand was played.
+func (cli *VideoPokerCLI) record() error {
+	if cli.ProfileDir == "" || cli.game.Stats.Hands == 0 {
+		return nil
+	}
+	key := cli.game.Machine.Name + " " + cli.game.Paytable.Name
+	_, err := profile.Update(cli.ProfileDir, func(p *profile.Profile) {
+		p.RecordVideoPoker(key, cli.game.Stats)
+	})
+	return err
+}
+
+func videoPokerLabel(card entity.Trump) string {
+	if card == videopoker.Joker {
+		return "🃏"
+	}
+	return cardLabel(card)
+}
+
+func videoPokerLabels(cards []entity.Trump) string {
+	var labels []string
+	for _, card := range cards {
+		labels = append(labels, videoPokerLabel(card))
+	}
+	return strings.Join(labels, " ")
+}